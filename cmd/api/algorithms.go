@@ -0,0 +1,250 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/validators"
+	"net/http"
+)
+
+type transformRequest struct {
+	Algorithm string `json:"algorithm"`
+	Transform string `json:"transform"`
+	Plane     string `json:"plane"`
+	Rotation  string `json:"rotation"`
+}
+
+func TransformHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req transformRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	var validationErrors []ValidationError
+	if err := validators.ValidateAlgorithm(req.Algorithm); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "algorithm",
+			Message: err.Error(),
+		})
+	}
+
+	if err := validators.ValidateTransform(req.Transform); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "transform",
+			Message: err.Error(),
+		})
+	}
+
+	if req.Transform == "mirror" {
+		if err := validators.ValidatePlane(req.Plane); err != nil {
+			validationErrors = append(validationErrors, ValidationError{
+				Field:   "plane",
+				Message: err.Error(),
+			})
+		}
+	}
+
+	if req.Transform == "rotate" {
+		if err := validators.ValidateRotation(req.Rotation); err != nil {
+			validationErrors = append(validationErrors, ValidationError{
+				Field:   "rotation",
+				Message: err.Error(),
+			})
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		respondWithValidationError(w, validationErrors)
+		return
+	}
+
+	alg, err := models.ParseAlgorithm(req.Algorithm)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var result models.Algorithm
+	switch req.Transform {
+	case "inverse":
+		result = alg.Inverse()
+	case "mirror":
+		result, err = alg.Mirror(req.Plane)
+	case "rotate":
+		result, err = alg.Rotate(req.Rotation)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":   true,
+		"algorithm": alg.String(),
+		"result":    result.String(),
+		"metrics":   result.Metrics(),
+	})
+}
+
+type compareRequest struct {
+	First    string `json:"first"`
+	Second   string `json:"second"`
+	AUF      bool   `json:"auf"`
+	Rotation bool   `json:"rotation"`
+}
+
+func CompareHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req compareRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	var validationErrors []ValidationError
+	if err := validators.ValidateAlgorithm(req.First); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "first",
+			Message: err.Error(),
+		})
+	}
+
+	if err := validators.ValidateAlgorithm(req.Second); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "second",
+			Message: err.Error(),
+		})
+	}
+
+	if len(validationErrors) > 0 {
+		respondWithValidationError(w, validationErrors)
+		return
+	}
+
+	first, err := models.ParseAlgorithm(req.First)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	second, err := models.ParseAlgorithm(req.Second)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	equivalence := models.CompareAlgorithms(first, second, models.EquivalenceOptions{
+		AUF:      req.AUF,
+		Rotation: req.Rotation,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":    true,
+		"equivalent": equivalence.Equivalent,
+		"preAuf":     equivalence.PreAUF,
+		"postAuf":    equivalence.PostAUF,
+		"rotation":   equivalence.Rotation,
+	})
+}
+
+type commutatorRequest struct {
+	Stickers []string `json:"stickers"`
+	Limit    int      `json:"limit"`
+}
+
+const (
+	defaultCommutatorLimit = 10
+	maxCommutatorLimit     = 50
+)
+
+func CommutatorHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req commutatorRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	var validationErrors []ValidationError
+	if err := validators.ValidateThreeCycle(req.Stickers); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "stickers",
+			Message: err.Error(),
+		})
+	}
+
+	if err := validators.ValidateLimit(req.Limit, maxCommutatorLimit); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "limit",
+			Message: err.Error(),
+		})
+	}
+
+	if len(validationErrors) > 0 {
+		respondWithValidationError(w, validationErrors)
+		return
+	}
+
+	target, err := models.ThreeCycle([3]string{req.Stickers[0], req.Stickers[1], req.Stickers[2]})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultCommutatorLimit
+	}
+
+	commutators, err := models.FindCommutators(target, limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":     true,
+		"commutators": commutators,
+	})
+}
@@ -0,0 +1,446 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestTransformHandler tests the TransformHandler function
+func TestTransformHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		requestBody    map[string]interface{}
+		expectedStatus int
+		expectedResult string
+		expectedErrors []ValidationError
+	}{
+		{
+			name: "Inverse",
+			requestBody: map[string]interface{}{
+				"algorithm": "R U R' F2",
+				"transform": "inverse",
+			},
+			expectedStatus: http.StatusOK,
+			expectedResult: "F2 R U' R'",
+		},
+		{
+			name: "Mirror M",
+			requestBody: map[string]interface{}{
+				"algorithm": "R U R' U'",
+				"transform": "mirror",
+				"plane":     "M",
+			},
+			expectedStatus: http.StatusOK,
+			expectedResult: "L' U' L U",
+		},
+		{
+			name: "Rotate y",
+			requestBody: map[string]interface{}{
+				"algorithm": "R U R'",
+				"transform": "rotate",
+				"rotation":  "y",
+			},
+			expectedStatus: http.StatusOK,
+			expectedResult: "B U B'",
+		},
+		{
+			name: "Invalid Algorithm",
+			requestBody: map[string]interface{}{
+				"algorithm": "R Q",
+				"transform": "inverse",
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "algorithm",
+					Message: "invalid algorithm: invalid move \"Q\" at position 2",
+				},
+			},
+		},
+		{
+			name: "Algorithm Too Long",
+			requestBody: map[string]interface{}{
+				"algorithm": strings.Repeat("R ", 501),
+				"transform": "inverse",
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "algorithm",
+					Message: "algorithm must be at most 1000 characters",
+				},
+			},
+		},
+		{
+			name: "Nested Brackets Expand Too Far",
+			requestBody: map[string]interface{}{
+				"algorithm": strings.Repeat("[", 22) + "R, U" + strings.Repeat("], U", 21) + "]",
+				"transform": "inverse",
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "algorithm",
+					Message: "invalid algorithm: algorithm expands to more than 5000 moves at position 11",
+				},
+			},
+		},
+		{
+			name: "Missing Plane",
+			requestBody: map[string]interface{}{
+				"algorithm": "R U",
+				"transform": "mirror",
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "plane",
+					Message: "plane cannot be empty",
+				},
+			},
+		},
+		{
+			name: "Invalid Transform And Empty Algorithm",
+			requestBody: map[string]interface{}{
+				"algorithm": "",
+				"transform": "flip",
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "algorithm",
+					Message: "algorithm cannot be empty",
+				},
+				{
+					Field:   "transform",
+					Message: "invalid transform: flip. Valid transforms are: inverse, mirror, rotate",
+				},
+			},
+		},
+		{
+			name: "Invalid Rotation",
+			requestBody: map[string]interface{}{
+				"algorithm": "R U",
+				"transform": "rotate",
+				"rotation":  "R",
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "rotation",
+					Message: "invalid rotation: R. Valid examples: x, y, z, x', y', z', x2, y2, z2",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body, _ := json.Marshal(tc.requestBody)
+
+			req, _ := http.NewRequest("POST", "/api/algorithms/transform", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(TransformHandler)
+			handler.ServeHTTP(rr, req)
+
+			// Check the status code
+			if status := rr.Code; status != tc.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tc.expectedStatus)
+			}
+
+			// For error cases, verify the error response
+			if tc.expectedErrors != nil {
+				var response ValidationResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to unmarshal error response: %v", err)
+				}
+
+				if len(response.Errors) != len(tc.expectedErrors) {
+					t.Fatalf("Expected %d errors, got %d", len(tc.expectedErrors), len(response.Errors))
+				}
+
+				for i, expectedErr := range tc.expectedErrors {
+					if response.Errors[i] != expectedErr {
+						t.Errorf("Error mismatch:\nExpected: %+v\nActual: %+v", expectedErr, response.Errors[i])
+					}
+				}
+			}
+
+			// For success cases, verify the transformed algorithm
+			if tc.expectedStatus == http.StatusOK {
+				var response map[string]interface{}
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to unmarshal success response: %v", err)
+				}
+
+				if response["success"] != true {
+					t.Errorf("Expected success to be true for success response")
+				}
+
+				if response["result"] != tc.expectedResult {
+					t.Errorf("Expected result %q, got %v", tc.expectedResult, response["result"])
+				}
+
+				// Verify move counts are reported for the result
+				metrics, ok := response["metrics"].(map[string]interface{})
+				if !ok {
+					t.Fatalf("Expected metrics in success response")
+				}
+				for _, metric := range []string{"htm", "qtm", "stm", "etm"} {
+					if _, ok := metrics[metric]; !ok {
+						t.Errorf("Expected %s in metrics, got %v", metric, metrics)
+					}
+				}
+			}
+		})
+	}
+
+	// Test non-POST request
+	req, _ := http.NewRequest("GET", "/api/algorithms/transform", nil)
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(TransformHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusMethodNotAllowed {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusMethodNotAllowed)
+	}
+}
+
+// TestCompareHandler tests the CompareHandler function
+func TestCompareHandler(t *testing.T) {
+	testCases := []struct {
+		name             string
+		requestBody      map[string]interface{}
+		expectedStatus   int
+		expectedResponse map[string]interface{}
+		expectedErrors   []ValidationError
+	}{
+		{
+			name: "Equivalent With AUF",
+			requestBody: map[string]interface{}{
+				"first":  "R U R' U R U2 R'",
+				"second": "U' R U R' U R U2 R' U2",
+				"auf":    true,
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: map[string]interface{}{
+				"success":    true,
+				"equivalent": true,
+				"preAuf":     "U",
+				"postAuf":    "U2",
+				"rotation":   "",
+			},
+		},
+		{
+			name: "Equivalent With Rotation",
+			requestBody: map[string]interface{}{
+				"first":    "R U R' U'",
+				"second":   "F U F' U'",
+				"rotation": true,
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: map[string]interface{}{
+				"success":    true,
+				"equivalent": true,
+				"preAuf":     "",
+				"postAuf":    "",
+				"rotation":   "y",
+			},
+		},
+		{
+			name: "Not Equivalent",
+			requestBody: map[string]interface{}{
+				"first":  "R U R' U'",
+				"second": "F U F' U'",
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: map[string]interface{}{
+				"success":    true,
+				"equivalent": false,
+				"preAuf":     "",
+				"postAuf":    "",
+				"rotation":   "",
+			},
+		},
+		{
+			name: "Invalid Algorithms",
+			requestBody: map[string]interface{}{
+				"first":  "",
+				"second": "R X",
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "first",
+					Message: "algorithm cannot be empty",
+				},
+				{
+					Field:   "second",
+					Message: "invalid algorithm: invalid move \"X\" at position 2",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body, _ := json.Marshal(tc.requestBody)
+
+			req, _ := http.NewRequest("POST", "/api/algorithms/compare", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(CompareHandler)
+			handler.ServeHTTP(rr, req)
+
+			// Check the status code
+			if status := rr.Code; status != tc.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tc.expectedStatus)
+			}
+
+			// For error cases, verify the error response
+			if tc.expectedErrors != nil {
+				var response ValidationResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to unmarshal error response: %v", err)
+				}
+
+				if !reflect.DeepEqual(response.Errors, tc.expectedErrors) {
+					t.Errorf("Error mismatch:\nExpected: %+v\nActual: %+v", tc.expectedErrors, response.Errors)
+				}
+			}
+
+			// For success cases, verify the comparison result
+			if tc.expectedResponse != nil {
+				var response map[string]interface{}
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to unmarshal success response: %v", err)
+				}
+
+				if !reflect.DeepEqual(response, tc.expectedResponse) {
+					t.Errorf("Expected response %v, got %v", tc.expectedResponse, response)
+				}
+			}
+		})
+	}
+}
+
+// TestCommutatorHandler tests the CommutatorHandler function
+func TestCommutatorHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		requestBody    map[string]interface{}
+		expectedStatus int
+		expectedFirst  string
+		expectedErrors []ValidationError
+	}{
+		{
+			name: "Edge Cycle",
+			requestBody: map[string]interface{}{
+				"stickers": []string{"UF", "UB", "DF"},
+				"limit":    3,
+			},
+			expectedStatus: http.StatusOK,
+			expectedFirst:  "[U2, M']",
+		},
+		{
+			name: "Corner Cycle",
+			requestBody: map[string]interface{}{
+				"stickers": []string{"UFR", "RDF", "LUB"},
+			},
+			expectedStatus: http.StatusOK,
+			expectedFirst:  "[F, R' B2 R]",
+		},
+		{
+			name: "Mixed Pieces",
+			requestBody: map[string]interface{}{
+				"stickers": []string{"UFR", "UB", "DF"},
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "stickers",
+					Message: "invalid cycle: stickers must be all corners or all edges",
+				},
+			},
+		},
+		{
+			name: "Wrong Sticker Count And Limit",
+			requestBody: map[string]interface{}{
+				"stickers": []string{"UF", "UB"},
+				"limit":    100,
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "stickers",
+					Message: "cycle must name exactly 3 stickers, got 2",
+				},
+				{
+					Field:   "limit",
+					Message: "limit must be between 0 and 50",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body, _ := json.Marshal(tc.requestBody)
+
+			req, _ := http.NewRequest("POST", "/api/commutators", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(CommutatorHandler)
+			handler.ServeHTTP(rr, req)
+
+			// Check the status code
+			if status := rr.Code; status != tc.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tc.expectedStatus)
+			}
+
+			// For error cases, verify the error response
+			if tc.expectedErrors != nil {
+				var response ValidationResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to unmarshal error response: %v", err)
+				}
+
+				if !reflect.DeepEqual(response.Errors, tc.expectedErrors) {
+					t.Errorf("Error mismatch:\nExpected: %+v\nActual: %+v", tc.expectedErrors, response.Errors)
+				}
+			}
+
+			// For success cases, verify the best candidate is listed first
+			if tc.expectedStatus == http.StatusOK {
+				var response struct {
+					Success     bool                `json:"success"`
+					Commutators []models.Commutator `json:"commutators"`
+				}
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to unmarshal success response: %v", err)
+				}
+
+				if !response.Success || len(response.Commutators) == 0 {
+					t.Fatalf("Expected commutators in success response")
+				}
+
+				if response.Commutators[0].Notation != tc.expectedFirst {
+					t.Errorf("Expected %s first, got %s", tc.expectedFirst, response.Commutators[0].Notation)
+				}
+			}
+		})
+	}
+}
@@ -141,6 +141,72 @@ func (cm *CubeManager) MoveHandler(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+type algorithmRequest struct {
+	Algorithm string `json:"algorithm"`
+	NISS      bool   `json:"niss"`
+}
+
+// AlgorithmHandler applies an algorithm to the cube. With niss set, moves in
+// parentheses are taken as done on the inverse scramble and the equivalent
+// normal moves are applied.
+func (cm *CubeManager) AlgorithmHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req algorithmRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	validate, parse := validators.ValidateAlgorithm, models.ParseAlgorithm
+	if req.NISS {
+		validate, parse = validators.ValidateNISS, models.ParseNISS
+	}
+
+	var validationErrors []ValidationError
+	if err := validate(req.Algorithm); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "algorithm",
+			Message: err.Error(),
+		})
+	}
+
+	if len(validationErrors) > 0 {
+		respondWithValidationError(w, validationErrors)
+		return
+	}
+
+	alg, err := parse(req.Algorithm)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	cm.cube.Apply(alg)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":   true,
+		"algorithm": alg.String(),
+		"metrics":   alg.Metrics(),
+		"cube":      cm.cube,
+	})
+}
+
 func (cm *CubeManager) ResetHandler(w http.ResponseWriter, r *http.Request) {
 	enableCORS(&w)
 
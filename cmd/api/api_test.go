@@ -369,6 +369,150 @@ func TestMoveHandlerValidation(t *testing.T) {
 	}
 }
 
+// TestAlgorithmHandler tests applying whole algorithms to the managed cube
+func TestAlgorithmHandler(t *testing.T) {
+	testCases := []struct {
+		name              string
+		requestBody       map[string]interface{}
+		expectedStatus    int
+		expectedAlgorithm string
+		expectedErrors    []ValidationError
+	}{
+		{
+			name: "Plain Moves",
+			requestBody: map[string]interface{}{
+				"algorithm": "R U R' U'",
+			},
+			expectedStatus:    http.StatusOK,
+			expectedAlgorithm: "R U R' U'",
+		},
+		{
+			name: "Commutator",
+			requestBody: map[string]interface{}{
+				"algorithm": "[R U R', D]",
+			},
+			expectedStatus:    http.StatusOK,
+			expectedAlgorithm: "R U R' D R U' R' D'",
+		},
+		{
+			name: "Nested Conjugate",
+			requestBody: map[string]interface{}{
+				"algorithm": "[U: [R' D R, U2]]",
+			},
+			expectedStatus:    http.StatusOK,
+			expectedAlgorithm: "U R' D R U2 R' D' R U2 U'",
+		},
+		{
+			name: "NISS",
+			requestBody: map[string]interface{}{
+				"algorithm": "F (R U) D",
+				"niss":      true,
+			},
+			expectedStatus:    http.StatusOK,
+			expectedAlgorithm: "F D U' R'",
+		},
+		{
+			name: "Parentheses Without NISS",
+			requestBody: map[string]interface{}{
+				"algorithm": "F (R U) D",
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "algorithm",
+					Message: "invalid algorithm: invalid move \"(\" at position 2",
+				},
+			},
+		},
+		{
+			name: "Unclosed Bracket",
+			requestBody: map[string]interface{}{
+				"algorithm": "R [U: R' D R",
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "algorithm",
+					Message: "invalid algorithm: unclosed bracket at position 2",
+				},
+			},
+		},
+		{
+			name: "Empty Algorithm",
+			requestBody: map[string]interface{}{
+				"algorithm": "",
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "algorithm",
+					Message: "algorithm cannot be empty",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cm := NewCubeManager()
+
+			body, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest("POST", "/api/cube/algorithm", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(cm.AlgorithmHandler)
+			handler.ServeHTTP(rr, req)
+
+			// Check the status code
+			if status := rr.Code; status != tc.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tc.expectedStatus)
+			}
+
+			// For error cases, verify the error response
+			if tc.expectedErrors != nil {
+				var response ValidationResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to unmarshal error response: %v", err)
+				}
+
+				if !reflect.DeepEqual(response.Errors, tc.expectedErrors) {
+					t.Errorf("Error mismatch:\nExpected: %+v\nActual: %+v", tc.expectedErrors, response.Errors)
+				}
+			}
+
+			// For success cases, verify the managed cube received the expanded moves
+			if tc.expectedStatus == http.StatusOK {
+				var response struct {
+					Success   bool              `json:"success"`
+					Algorithm string            `json:"algorithm"`
+					Metrics   models.MoveCount  `json:"metrics"`
+					Cube      models.RubiksCube `json:"cube"`
+				}
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to unmarshal success response: %v", err)
+				}
+
+				if !response.Success || response.Algorithm != tc.expectedAlgorithm {
+					t.Errorf("Expected algorithm %q, got %q", tc.expectedAlgorithm, response.Algorithm)
+				}
+
+				alg, _ := models.ParseAlgorithm(tc.expectedAlgorithm)
+				if response.Metrics != alg.Metrics() {
+					t.Errorf("Expected metrics %+v, got %+v", alg.Metrics(), response.Metrics)
+				}
+
+				expected := models.New()
+				expected.ApplyAlgorithm(tc.expectedAlgorithm)
+				if !reflect.DeepEqual(*cm.cube, *expected) || !reflect.DeepEqual(response.Cube, *expected) {
+					t.Errorf("Managed cube does not match the applied algorithm")
+				}
+			}
+		})
+	}
+}
+
 func TestResetHandler(t *testing.T) {
 	// Create a new cube manager
 	cm := NewCubeManager()
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/validators"
+	"net/http"
+	"strings"
+)
+
+type memoRequest struct {
+	CornerBuffer string            `json:"cornerBuffer"`
+	EdgeBuffer   string            `json:"edgeBuffer"`
+	Lettering    map[string]string `json:"lettering"`
+	Corners      string            `json:"corners"`
+	Edges        string            `json:"edges"`
+}
+
+type memoCheck struct {
+	Corners bool `json:"corners"`
+	Edges   bool `json:"edges"`
+	Correct bool `json:"correct"`
+}
+
+// MemoHandler returns the blindfolded memo of the current cube. A GET takes
+// the buffers as query parameters; a POST also checks the memo the user typed.
+func (cm *CubeManager) MemoHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	var req memoRequest
+	switch r.Method {
+	case http.MethodGet:
+		req.CornerBuffer = r.URL.Query().Get("cornerBuffer")
+		req.EdgeBuffer = r.URL.Query().Get("edgeBuffer")
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var validationErrors []ValidationError
+	if err := validators.ValidateCornerBuffer(req.CornerBuffer); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "cornerBuffer",
+			Message: err.Error(),
+		})
+	}
+
+	if err := validators.ValidateEdgeBuffer(req.EdgeBuffer); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "edgeBuffer",
+			Message: err.Error(),
+		})
+	}
+
+	if err := validators.ValidateLettering(req.Lettering); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "lettering",
+			Message: err.Error(),
+		})
+	}
+
+	if len(validationErrors) > 0 {
+		respondWithValidationError(w, validationErrors)
+		return
+	}
+
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
+
+	memo, err := cm.cube.Memo(models.MemoOptions{
+		CornerBuffer: req.CornerBuffer,
+		EdgeBuffer:   req.EdgeBuffer,
+		Lettering:    req.Lettering,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response := map[string]interface{}{
+		"success": true,
+		"memo":    memo,
+	}
+
+	if r.Method == http.MethodPost {
+		check := memoCheck{
+			Corners: normalizeMemo(req.Corners) == normalizeMemo(strings.Join(memo.Corners, "")),
+			Edges:   normalizeMemo(req.Edges) == normalizeMemo(strings.Join(memo.Edges, "")),
+		}
+		check.Correct = check.Corners && check.Edges
+		response["check"] = check
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func normalizeMemo(memo string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(memo), ""))
+}
+
+// ExecutionHandler returns the Old Pochmann or M2 execution that solves the
+// current cube blindfolded, one step per target.
+func (cm *CubeManager) ExecutionHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	method := r.URL.Query().Get("method")
+	if method == "" {
+		method = models.MethodOldPochmann
+	}
+
+	var validationErrors []ValidationError
+	if err := validators.ValidateExecutionMethod(method); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "method",
+			Message: err.Error(),
+		})
+	}
+
+	if len(validationErrors) > 0 {
+		respondWithValidationError(w, validationErrors)
+		return
+	}
+
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
+
+	execution, err := cm.cube.Execution(method)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":   true,
+		"execution": execution,
+	})
+}
@@ -0,0 +1,238 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// TestMemoHandler tests the MemoHandler function
+func TestMemoHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		requestBody    map[string]interface{}
+		expectedStatus int
+		expectedMemo   []string
+		expectedCheck  *memoCheck
+		expectedErrors []ValidationError
+	}{
+		{
+			name:           "Default Buffers",
+			method:         "GET",
+			url:            "/api/cube/memo",
+			expectedStatus: http.StatusOK,
+			expectedMemo:   []string{"P", "C"},
+		},
+		{
+			name:           "Custom Corner Buffer",
+			method:         "GET",
+			url:            "/api/cube/memo?cornerBuffer=UFR",
+			expectedStatus: http.StatusOK,
+			expectedMemo:   []string{"A", "P"},
+		},
+		{
+			name:   "Correct Typed Memo",
+			method: "POST",
+			url:    "/api/cube/memo",
+			requestBody: map[string]interface{}{
+				"corners": "p c",
+				"edges":   "",
+			},
+			expectedStatus: http.StatusOK,
+			expectedMemo:   []string{"P", "C"},
+			expectedCheck:  &memoCheck{Corners: true, Edges: true, Correct: true},
+		},
+		{
+			name:   "Wrong Typed Memo",
+			method: "POST",
+			url:    "/api/cube/memo",
+			requestBody: map[string]interface{}{
+				"corners": "CP",
+				"edges":   "",
+			},
+			expectedStatus: http.StatusOK,
+			expectedMemo:   []string{"P", "C"},
+			expectedCheck:  &memoCheck{Corners: false, Edges: true, Correct: false},
+		},
+		{
+			name:   "Invalid Buffers And Lettering",
+			method: "POST",
+			url:    "/api/cube/memo",
+			requestBody: map[string]interface{}{
+				"cornerBuffer": "UF",
+				"edgeBuffer":   "UXR",
+				"lettering":    map[string]string{"UF": ""},
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "cornerBuffer",
+					Message: "invalid corner buffer: UF",
+				},
+				{
+					Field:   "edgeBuffer",
+					Message: "invalid edge buffer: UXR",
+				},
+				{
+					Field:   "lettering",
+					Message: "invalid lettering: letter for UF cannot be empty",
+				},
+			},
+		},
+		{
+			name:           "Method Not Allowed",
+			method:         "PUT",
+			url:            "/api/cube/memo",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Set up a cube with a corner 3-cycle from the UBL buffer
+			cm := NewCubeManager()
+			cm.cube, _ = models.ThreeCycle([3]string{"UBL", "UFR", "RDF"})
+
+			var body []byte
+			if tc.requestBody != nil {
+				body, _ = json.Marshal(tc.requestBody)
+			}
+			req, _ := http.NewRequest(tc.method, tc.url, bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(cm.MemoHandler)
+			handler.ServeHTTP(rr, req)
+
+			// Check the status code
+			if status := rr.Code; status != tc.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tc.expectedStatus)
+			}
+
+			// For error cases, verify the error response
+			if tc.expectedErrors != nil {
+				var response ValidationResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to unmarshal error response: %v", err)
+				}
+
+				if !reflect.DeepEqual(response.Errors, tc.expectedErrors) {
+					t.Errorf("Error mismatch:\nExpected: %+v\nActual: %+v", tc.expectedErrors, response.Errors)
+				}
+			}
+
+			// For success cases, verify the memo and the check
+			if tc.expectedStatus == http.StatusOK {
+				var response struct {
+					Success bool        `json:"success"`
+					Memo    models.Memo `json:"memo"`
+					Check   *memoCheck  `json:"check"`
+				}
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to unmarshal success response: %v", err)
+				}
+
+				if !response.Success || !reflect.DeepEqual(response.Memo.Corners, tc.expectedMemo) {
+					t.Errorf("Expected corner memo %v, got %v", tc.expectedMemo, response.Memo.Corners)
+				}
+
+				if !reflect.DeepEqual(response.Check, tc.expectedCheck) {
+					t.Errorf("Expected check %+v, got %+v", tc.expectedCheck, response.Check)
+				}
+			}
+		})
+	}
+}
+
+// TestExecutionHandler tests the ExecutionHandler function
+func TestExecutionHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		url            string
+		expectedStatus int
+		expectedMethod string
+		expectedErrors []ValidationError
+	}{
+		{
+			name:           "Default Method",
+			url:            "/api/cube/execution",
+			expectedStatus: http.StatusOK,
+			expectedMethod: "op",
+		},
+		{
+			name:           "M2 Edges",
+			url:            "/api/cube/execution?method=m2",
+			expectedStatus: http.StatusOK,
+			expectedMethod: "m2",
+		},
+		{
+			name:           "Invalid Method",
+			url:            "/api/cube/execution?method=3style",
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "method",
+					Message: "invalid method: 3style. Valid methods are: op, m2",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Set up a scrambled cube
+			cm := NewCubeManager()
+			cm.cube.ApplyAlgorithm("R U R' U' R' F R2 U' R' U' R U R' F' D B2")
+
+			req, _ := http.NewRequest("GET", tc.url, nil)
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(cm.ExecutionHandler)
+			handler.ServeHTTP(rr, req)
+
+			// Check the status code
+			if status := rr.Code; status != tc.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tc.expectedStatus)
+			}
+
+			// For error cases, verify the error response
+			if tc.expectedErrors != nil {
+				var response ValidationResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to unmarshal error response: %v", err)
+				}
+
+				if !reflect.DeepEqual(response.Errors, tc.expectedErrors) {
+					t.Errorf("Error mismatch:\nExpected: %+v\nActual: %+v", tc.expectedErrors, response.Errors)
+				}
+			}
+
+			// For success cases, verify the execution solves the managed cube
+			if tc.expectedStatus == http.StatusOK {
+				var response struct {
+					Success   bool             `json:"success"`
+					Execution models.Execution `json:"execution"`
+				}
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to unmarshal success response: %v", err)
+				}
+
+				if !response.Success || response.Execution.Method != tc.expectedMethod || len(response.Execution.Steps) == 0 {
+					t.Errorf("Unexpected execution: %+v", response.Execution)
+				}
+
+				cm.cube.Apply(response.Execution.Algorithm)
+				if !reflect.DeepEqual(*cm.cube, *models.New()) {
+					t.Errorf("Expected the execution to solve the cube")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/store"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/validators"
+	"net/http"
+	"strconv"
+)
+
+type CollectionManager struct {
+	store *store.AlgorithmStore
+}
+
+func NewCollectionManager(algorithms *store.AlgorithmStore) *CollectionManager {
+	return &CollectionManager{
+		store: algorithms,
+	}
+}
+
+type storedAlgorithmRequest struct {
+	Name      string   `json:"name"`
+	Algorithm string   `json:"algorithm"`
+	Tags      []string `json:"tags"`
+	Notes     string   `json:"notes"`
+}
+
+// AlgorithmsHandler serves the user-defined algorithms: GET lists them,
+// filtered by tag or case, or returns one by id; POST creates one; PUT and
+// DELETE update and delete the one given by id.
+func (cm *CollectionManager) AlgorithmsHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		cm.getAlgorithms(w, r)
+	case http.MethodPost:
+		cm.saveAlgorithm(w, r, 0)
+	case http.MethodPut, http.MethodDelete:
+		id, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			respondWithValidationError(w, []ValidationError{{
+				Field:   "id",
+				Message: "id must be a number",
+			}})
+			return
+		}
+
+		if r.Method == http.MethodPut {
+			cm.saveAlgorithm(w, r, id)
+		} else {
+			cm.deleteAlgorithm(w, id)
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (cm *CollectionManager) getAlgorithms(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	if query.Get("id") != "" {
+		id, err := strconv.Atoi(query.Get("id"))
+		if err != nil {
+			respondWithValidationError(w, []ValidationError{{
+				Field:   "id",
+				Message: "id must be a number",
+			}})
+			return
+		}
+
+		algorithm, err := cm.store.Get(id)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success":   true,
+			"algorithm": algorithm,
+		})
+		return
+	}
+
+	algorithms := cm.store.List(store.Filter{
+		Tag:  query.Get("tag"),
+		Case: query.Get("case"),
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":    true,
+		"algorithms": algorithms,
+	})
+}
+
+// saveAlgorithm creates a new algorithm, or replaces the one with the given
+// id when it is not zero.
+func (cm *CollectionManager) saveAlgorithm(w http.ResponseWriter, r *http.Request, id int) {
+	var req storedAlgorithmRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	var validationErrors []ValidationError
+	if err := validators.ValidateAlgorithmName(req.Name); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "name",
+			Message: err.Error(),
+		})
+	}
+
+	if err := validators.ValidateStoredAlgorithm(req.Algorithm); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "algorithm",
+			Message: err.Error(),
+		})
+	}
+
+	if err := validators.ValidateTags(req.Tags); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "tags",
+			Message: err.Error(),
+		})
+	}
+
+	if len(validationErrors) > 0 {
+		respondWithValidationError(w, validationErrors)
+		return
+	}
+
+	alg, _ := models.ParseAlgorithm(req.Algorithm)
+	algorithm := store.Algorithm{
+		Name:      req.Name,
+		Algorithm: alg,
+		Tags:      req.Tags,
+		Notes:     req.Notes,
+	}
+
+	status := http.StatusCreated
+	if id == 0 {
+		algorithm, err = cm.store.Create(algorithm)
+	} else {
+		status = http.StatusOK
+		algorithm, err = cm.store.Update(id, algorithm)
+	}
+
+	if errors.Is(err, store.ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":   true,
+		"algorithm": algorithm,
+	})
+}
+
+func (cm *CollectionManager) deleteAlgorithm(w http.ResponseWriter, id int) {
+	err := cm.store.Delete(id)
+	if errors.Is(err, store.ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Algorithm has been deleted",
+	})
+}
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/store"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func newTestCollectionManager(t *testing.T) *CollectionManager {
+	algorithms, err := store.NewAlgorithmStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	return NewCollectionManager(algorithms)
+}
+
+func serveAlgorithms(cm *CollectionManager, method, url string, body interface{}) *httptest.ResponseRecorder {
+	var buffer bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&buffer).Encode(body)
+	}
+
+	req, _ := http.NewRequest(method, url, &buffer)
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(cm.AlgorithmsHandler).ServeHTTP(rr, req)
+	return rr
+}
+
+// TestAlgorithmsHandlerCRUD tests creating, listing, updating and deleting
+// user-defined algorithms
+func TestAlgorithmsHandlerCRUD(t *testing.T) {
+	cm := newTestCollectionManager(t)
+
+	// Step 1: Create two algorithms
+	rr := serveAlgorithms(cm, "POST", "/api/algorithms", map[string]interface{}{
+		"name":      "Sune",
+		"algorithm": "R U R' U R U2 R'",
+		"tags":      []string{"oll", "2-look"},
+	})
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusCreated)
+	}
+
+	var created struct {
+		Success   bool            `json:"success"`
+		Algorithm store.Algorithm `json:"algorithm"`
+	}
+	json.Unmarshal(rr.Body.Bytes(), &created)
+	if !created.Success || created.Algorithm.ID != 1 || !reflect.DeepEqual(created.Algorithm.Cases, []string{"oll-27", "ocll-sune"}) {
+		t.Errorf("Unexpected created algorithm: %+v", created.Algorithm)
+	}
+
+	serveAlgorithms(cm, "POST", "/api/algorithms", map[string]interface{}{
+		"name":      "Ua perm",
+		"algorithm": "R U' R U R U R U' R' U' R2",
+		"tags":      []string{"pll"},
+	})
+
+	// Step 2: Search by tag and by case
+	var list struct {
+		Success    bool              `json:"success"`
+		Algorithms []store.Algorithm `json:"algorithms"`
+	}
+
+	rr = serveAlgorithms(cm, "GET", "/api/algorithms?tag=pll", nil)
+	json.Unmarshal(rr.Body.Bytes(), &list)
+	if len(list.Algorithms) != 1 || list.Algorithms[0].Name != "Ua perm" {
+		t.Errorf("Expected the Ua perm for tag pll, got %+v", list.Algorithms)
+	}
+
+	rr = serveAlgorithms(cm, "GET", "/api/algorithms?case=pll-ua", nil)
+	json.Unmarshal(rr.Body.Bytes(), &list)
+	if len(list.Algorithms) != 1 || list.Algorithms[0].Name != "Ua perm" {
+		t.Errorf("Expected the Ua perm for case pll-ua, got %+v", list.Algorithms)
+	}
+
+	// Step 3: Update the first algorithm
+	rr = serveAlgorithms(cm, "PUT", "/api/algorithms?id=1", map[string]interface{}{
+		"name":      "Antisune",
+		"algorithm": "R U2 R' U' R U' R'",
+		"notes":     "Mirror of Sune",
+	})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+	}
+
+	rr = serveAlgorithms(cm, "GET", "/api/algorithms?id=1", nil)
+	json.Unmarshal(rr.Body.Bytes(), &created)
+	if created.Algorithm.Name != "Antisune" || !reflect.DeepEqual(created.Algorithm.Cases, []string{"oll-26", "ocll-antisune"}) {
+		t.Errorf("Unexpected updated algorithm: %+v", created.Algorithm)
+	}
+
+	// Step 4: Delete it
+	rr = serveAlgorithms(cm, "DELETE", "/api/algorithms?id=1", nil)
+	if rr.Code != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+	}
+
+	rr = serveAlgorithms(cm, "GET", "/api/algorithms", nil)
+	json.Unmarshal(rr.Body.Bytes(), &list)
+	if len(list.Algorithms) != 1 {
+		t.Errorf("Expected one algorithm left, got %+v", list.Algorithms)
+	}
+
+	// Step 5: Missing algorithms
+	for _, method := range []string{"GET", "PUT", "DELETE"} {
+		rr = serveAlgorithms(cm, method, "/api/algorithms?id=1", map[string]interface{}{
+			"name":      "Sune",
+			"algorithm": "R U R' U R U2 R'",
+		})
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("%s returned wrong status code: got %v want %v", method, rr.Code, http.StatusNotFound)
+		}
+	}
+}
+
+// TestAlgorithmsHandlerValidation tests the validation of stored algorithms
+func TestAlgorithmsHandlerValidation(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		requestBody    map[string]interface{}
+		expectedStatus int
+		expectedErrors []ValidationError
+	}{
+		{
+			name:   "Missing Fields",
+			method: "POST",
+			url:    "/api/algorithms",
+			requestBody: map[string]interface{}{
+				"tags": []string{""},
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "name",
+					Message: "name cannot be empty",
+				},
+				{
+					Field:   "algorithm",
+					Message: "algorithm cannot be empty",
+				},
+				{
+					Field:   "tags",
+					Message: "tags cannot be empty",
+				},
+			},
+		},
+		{
+			name:   "Algorithm Without Effect",
+			method: "POST",
+			url:    "/api/algorithms",
+			requestBody: map[string]interface{}{
+				"name":      "Nothing",
+				"algorithm": "R R'",
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "algorithm",
+					Message: "algorithm does not change the cube",
+				},
+			},
+		},
+		{
+			name:           "Invalid ID",
+			method:         "DELETE",
+			url:            "/api/algorithms?id=abc",
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "id",
+					Message: "id must be a number",
+				},
+			},
+		},
+		{
+			name:           "Method Not Allowed",
+			method:         "PATCH",
+			url:            "/api/algorithms",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cm := newTestCollectionManager(t)
+			rr := serveAlgorithms(cm, tc.method, tc.url, tc.requestBody)
+
+			// Check the status code
+			if status := rr.Code; status != tc.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tc.expectedStatus)
+			}
+
+			// For error cases, verify the error response
+			if tc.expectedErrors != nil {
+				var response ValidationResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to unmarshal error response: %v", err)
+				}
+
+				if !reflect.DeepEqual(response.Errors, tc.expectedErrors) {
+					t.Errorf("Error mismatch:\nExpected: %+v\nActual: %+v", tc.expectedErrors, response.Errors)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/store"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/validators"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DailyManager hands out the scramble of the day, derived from the date and
+// a secret shared by every server, and keeps its leaderboard. Scrambles are
+// generated in the background, never under the context of a request, so a
+// slow or cancelled request cannot keep a date from getting its scramble.
+type DailyManager struct {
+	secret string
+	store  *store.DailyStore
+	mutex  sync.Mutex
+	days   map[string]*dailyScramble
+}
+
+// dailyScramble is the scramble of one date, ready once done is closed.
+type dailyScramble struct {
+	done     chan struct{}
+	scramble *models.Scramble
+	err      error
+}
+
+func NewDailyManager(secret string, s *store.DailyStore) *DailyManager {
+	return &DailyManager{
+		secret: secret,
+		store:  s,
+		days:   make(map[string]*dailyScramble),
+	}
+}
+
+// Start generates the scramble of today and then that of every new day at
+// UTC midnight, so that requests do not have to wait for it.
+func (dm *DailyManager) Start() {
+	go func() {
+		for {
+			now := time.Now().UTC()
+			dm.scrambleOf(models.DailyDate(now))
+
+			midnight := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+			time.Sleep(midnight.Sub(now))
+		}
+	}()
+}
+
+type dailySolutionRequest struct {
+	Name     string `json:"name"`
+	Solution string `json:"solution"`
+}
+
+// scrambleOf returns the scramble of the date, starting its generation if
+// it has not started yet. Scrambles of other dates are dropped.
+func (dm *DailyManager) scrambleOf(date string) *dailyScramble {
+	dm.mutex.Lock()
+	defer dm.mutex.Unlock()
+
+	if d, ok := dm.days[date]; ok {
+		return d
+	}
+	for other := range dm.days {
+		delete(dm.days, other)
+	}
+
+	d := &dailyScramble{done: make(chan struct{})}
+	dm.days[date] = d
+	go func() {
+		d.scramble, d.err = models.DailyScramble(context.Background(), dm.secret, date)
+		if d.err != nil {
+			// Let the next request try again
+			dm.mutex.Lock()
+			if dm.days[date] == d {
+				delete(dm.days, date)
+			}
+			dm.mutex.Unlock()
+		}
+		close(d.done)
+	}()
+	return d
+}
+
+// today returns the date and the scramble of the day, waiting for it if it
+// is still being generated. It reports false after responding with the
+// error when the scramble could not be generated or the request ended first.
+func (dm *DailyManager) today(w http.ResponseWriter, r *http.Request) (string, *models.Scramble, bool) {
+	date := models.DailyDate(time.Now())
+	d := dm.scrambleOf(date)
+
+	select {
+	case <-d.done:
+	case <-r.Context().Done():
+		http.Error(w, "request ended before the scramble was ready", http.StatusServiceUnavailable)
+		return "", nil, false
+	}
+
+	if d.err != nil {
+		http.Error(w, d.err.Error(), http.StatusInternalServerError)
+		return "", nil, false
+	}
+	return date, d.scramble, true
+}
+
+// DailyHandler returns the scramble of the day with its leaderboard.
+func (dm *DailyManager) DailyHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	date, scramble, ok := dm.today(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":     true,
+		"date":        date,
+		"scramble":    scramble,
+		"leaderboard": dm.store.Leaderboard(date),
+	})
+}
+
+// DailySolutionHandler checks a solution against the scramble of the day
+// and records it on the leaderboard.
+func (dm *DailyManager) DailySolutionHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req dailySolutionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	var validationErrors []ValidationError
+	if err := validators.ValidatePlayerName(req.Name); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "name",
+			Message: err.Error(),
+		})
+	}
+
+	if err := validators.ValidateAlgorithm(req.Solution); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "solution",
+			Message: err.Error(),
+		})
+	}
+
+	if len(validationErrors) > 0 {
+		respondWithValidationError(w, validationErrors)
+		return
+	}
+
+	date, scramble, ok := dm.today(w, r)
+	if !ok {
+		return
+	}
+
+	solution, _ := models.ParseAlgorithm(req.Solution)
+	if !models.VerifySolution(scramble.Scramble, solution) {
+		http.Error(w, fmt.Sprintf("solution does not solve the scramble of %s", date), http.StatusBadRequest)
+		return
+	}
+	if models.IsInverse(scramble.Scramble, solution) {
+		http.Error(w, fmt.Sprintf("solution only undoes the scramble of %s", date), http.StatusBadRequest)
+		return
+	}
+
+	result, rank, err := dm.store.Submit(store.DailyResult{
+		Date:     date,
+		Name:     req.Name,
+		Solution: solution,
+		Moves:    solution.Metrics().HTM,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":     true,
+		"result":      result,
+		"rank":        rank,
+		"leaderboard": dm.store.Leaderboard(date),
+	})
+}
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/store"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestDailyManager(t *testing.T) *DailyManager {
+	s, err := store.NewDailyStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return NewDailyManager("secret", s)
+}
+
+// TestDailyChallenge solves the scramble of the day through the endpoints
+func TestDailyChallenge(t *testing.T) {
+	dm := newTestDailyManager(t)
+
+	send := func(handler http.HandlerFunc, method, url, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, url, bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// Step 1: Get the scramble of the day
+	rr := send(dm.DailyHandler, "GET", "/api/daily", "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var daily struct {
+		Date        string              `json:"date"`
+		Scramble    models.Scramble     `json:"scramble"`
+		Leaderboard []store.DailyResult `json:"leaderboard"`
+	}
+	json.Unmarshal(rr.Body.Bytes(), &daily)
+	if daily.Date != models.DailyDate(time.Now()) || len(daily.Scramble.Scramble) == 0 || len(daily.Leaderboard) != 0 {
+		t.Fatalf("Unexpected response: %s", rr.Body.String())
+	}
+
+	// Step 2: A sequence that does not solve it is refused
+	rr = send(dm.DailySolutionHandler, "POST", "/api/daily/solution", `{"name":"Ana","solution":"R U R' U'"}`)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+
+	// Step 3: Undoing the scramble, even held the other way round, is refused
+	turned := map[string]string{"U": "U", "D": "D", "F": "B", "B": "F", "R": "L", "L": "R"}
+	rotated := models.Algorithm{{Face: "y", Amount: 2}}
+	for _, turn := range daily.Scramble.Scramble.Inverse() {
+		rotated = append(rotated, models.Turn{Face: turned[turn.Face], Amount: turn.Amount})
+	}
+	solution, _ := rotated.MarshalText()
+	rr = send(dm.DailySolutionHandler, "POST", "/api/daily/solution", `{"name":"Ana","solution":"`+string(solution)+`"}`)
+	if rr.Code != http.StatusBadRequest || !strings.Contains(rr.Body.String(), "solution only undoes the scramble") {
+		t.Errorf("Expected status %d for the inverse, got %d: %s", http.StatusBadRequest, rr.Code, rr.Body.String())
+	}
+
+	// Step 4: A solution with more moves than the inverse is accepted
+	extra, _ := models.ParseAlgorithm("R2 D2 U2 L2 R2 D2 U2 L2")
+	solution, _ = append(daily.Scramble.Scramble.Inverse(), extra...).MarshalText()
+	rr = send(dm.DailySolutionHandler, "POST", "/api/daily/solution", `{"name":"Ana","solution":"`+string(solution)+`"}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var submitted struct {
+		Result store.DailyResult `json:"result"`
+		Rank   int               `json:"rank"`
+	}
+	json.Unmarshal(rr.Body.Bytes(), &submitted)
+	if submitted.Rank != 1 || submitted.Result.Moves != daily.Scramble.Scramble.Metrics().HTM+8 {
+		t.Errorf("Unexpected result: %s", rr.Body.String())
+	}
+
+	// Step 5: The result is on the leaderboard
+	rr = send(dm.DailyHandler, "GET", "/api/daily", "")
+	json.Unmarshal(rr.Body.Bytes(), &daily)
+	if len(daily.Leaderboard) != 1 || daily.Leaderboard[0].Name != "Ana" {
+		t.Errorf("Unexpected leaderboard: %+v", daily.Leaderboard)
+	}
+}
+
+// TestDailySolutionErrors tests the validation of the solution endpoint
+func TestDailySolutionErrors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		body           string
+		expectedStatus int
+		expectedErrors []ValidationError
+	}{
+		{
+			name:           "Missing Name And Solution",
+			method:         "POST",
+			body:           `{}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "name",
+					Message: "name cannot be empty",
+				},
+				{
+					Field:   "solution",
+					Message: "algorithm cannot be empty",
+				},
+			},
+		},
+		{
+			name:           "Invalid Solution",
+			method:         "POST",
+			body:           `{"name":"Ana","solution":"Q"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "solution",
+					Message: "invalid algorithm: invalid move \"Q\" at position 0",
+				},
+			},
+		},
+		{
+			name:           "Method Not Allowed",
+			method:         "GET",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest(tc.method, "/api/daily/solution", bytes.NewBufferString(tc.body))
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(newTestDailyManager(t).DailySolutionHandler)
+
+			handler.ServeHTTP(rr, req)
+
+			// Check the status code
+			if status := rr.Code; status != tc.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tc.expectedStatus)
+			}
+
+			if tc.expectedErrors != nil {
+				var response ValidationResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to unmarshal error response: %v", err)
+				}
+
+				if !reflect.DeepEqual(response.Errors, tc.expectedErrors) {
+					t.Errorf("Error mismatch:\nExpected: %+v\nActual: %+v", tc.expectedErrors, response.Errors)
+				}
+			}
+		})
+	}
+}
+
+// TestDailyCancelledRequest checks that a request that ends early does not
+// keep the day from getting its scramble
+func TestDailyCancelledRequest(t *testing.T) {
+	dm := newTestDailyManager(t)
+
+	// Step 1: A request whose client is gone gets no scramble but no error either
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", "/api/daily", nil)
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(dm.DailyHandler).ServeHTTP(rr, req)
+	if rr.Code == http.StatusInternalServerError {
+		t.Fatalf("Unexpected error: %s", rr.Body.String())
+	}
+
+	// Step 2: The next request gets the scramble of the day
+	req, _ = http.NewRequest("GET", "/api/daily", nil)
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(dm.DailyHandler).ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+
+	var daily struct {
+		Date     string          `json:"date"`
+		Scramble models.Scramble `json:"scramble"`
+	}
+	json.Unmarshal(rr.Body.Bytes(), &daily)
+	expected, err := models.DailyScramble(context.Background(), "secret", daily.Date)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(daily.Scramble.Scramble, expected.Scramble) {
+		t.Errorf("Expected scramble %s, got %s", expected.Scramble, daily.Scramble.Scramble)
+	}
+}
@@ -0,0 +1,275 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/store"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/validators"
+	"math/rand"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// FMCManager hands out fewest-moves scrambles, keeps the attempts that have
+// not been submitted yet in memory and stores the scored results. Attempts
+// that are never submitted are dropped after fmcAttemptTTL, and a client can
+// have at most fmcAttemptsPerClient open at once so that it cannot push the
+// attempts of others out.
+type FMCManager struct {
+	store    *store.FMCStore
+	attempts *sessionStore[*fmcAttempt]
+	mutex    sync.Mutex
+}
+
+const (
+	// fmcAttemptTTL is the longest time limit an attempt can have and an
+	// hour more, so that a late submission is still scored as late.
+	fmcAttemptTTL = 25 * time.Hour
+	// fmcAttemptLimit caps the open attempts of all clients together.
+	fmcAttemptLimit = 10000
+	// fmcAttemptsPerClient caps the open attempts of one client address.
+	fmcAttemptsPerClient = 3
+)
+
+type fmcAttempt struct {
+	client    string
+	name      string
+	scramble  models.Algorithm
+	rules     models.FMCRules
+	startedAt time.Time
+}
+
+func NewFMCManager(s *store.FMCStore) *FMCManager {
+	return &FMCManager{
+		store:    s,
+		attempts: newSessionStore[*fmcAttempt](fmcAttemptTTL, fmcAttemptLimit),
+	}
+}
+
+type fmcStartRequest struct {
+	Name        string `json:"name"`
+	TimeLimit   *int   `json:"timeLimit"`
+	AllowSlices *bool  `json:"allowSlices"`
+	AllowWide   *bool  `json:"allowWide"`
+}
+
+type fmcSubmitRequest struct {
+	Attempt  string `json:"attempt"`
+	Solution string `json:"solution"`
+}
+
+// FMCStartHandler starts an attempt with a WCA-style fewest-moves scramble. The
+// WCA rules apply unless the request changes the time limit or which turns
+// are allowed.
+func (fm *FMCManager) FMCStartHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req fmcStartRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	var validationErrors []ValidationError
+	if err := validators.ValidatePlayerName(req.Name); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "name",
+			Message: err.Error(),
+		})
+	}
+
+	rules := models.DefaultFMCRules()
+	if req.TimeLimit != nil {
+		if err := validators.ValidateTimeLimit(*req.TimeLimit); err != nil {
+			validationErrors = append(validationErrors, ValidationError{
+				Field:   "timeLimit",
+				Message: err.Error(),
+			})
+		}
+		rules.TimeLimit = *req.TimeLimit
+	}
+
+	if len(validationErrors) > 0 {
+		respondWithValidationError(w, validationErrors)
+		return
+	}
+
+	if req.AllowSlices != nil {
+		rules.AllowSlices = *req.AllowSlices
+	}
+	if req.AllowWide != nil {
+		rules.AllowWide = *req.AllowWide
+	}
+
+	client := clientAddress(r)
+	if !fm.canStart(client) {
+		http.Error(w, tooManyAttempts, http.StatusTooManyRequests)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), solveTimeout)
+	defer cancel()
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	scramble, err := models.GenerateFMCScramble(ctx, rng)
+	if errors.Is(err, context.DeadlineExceeded) {
+		http.Error(w, "scramble generation timed out", http.StatusServiceUnavailable)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	attempt := &fmcAttempt{
+		client:    client,
+		name:      req.Name,
+		scramble:  scramble.Scramble,
+		rules:     rules,
+		startedAt: time.Now(),
+	}
+	id := newSessionID()
+
+	fm.mutex.Lock()
+	if fm.openAttempts(client) >= fmcAttemptsPerClient {
+		fm.mutex.Unlock()
+		http.Error(w, tooManyAttempts, http.StatusTooManyRequests)
+		return
+	}
+	fm.attempts.put(id, attempt)
+	fm.mutex.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"attempt":  id,
+		"scramble": scramble.Scramble,
+		"rules":    rules,
+		"deadline": attempt.startedAt.Add(time.Duration(rules.TimeLimit) * time.Second).UTC(),
+	})
+}
+
+const tooManyAttempts = "too many open attempts: submit one before starting another"
+
+// canStart reports whether the client may start another attempt. It is
+// checked again when the attempt is stored, since the scramble takes a
+// while to generate.
+func (fm *FMCManager) canStart(client string) bool {
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+	return fm.openAttempts(client) < fmcAttemptsPerClient
+}
+
+// openAttempts counts the attempts of the client that can still be
+// submitted. The caller must hold the mutex.
+func (fm *FMCManager) openAttempts(client string) int {
+	return fm.attempts.count(func(attempt *fmcAttempt) bool {
+		return attempt.client == client
+	})
+}
+
+// clientAddress returns the IP address of the client without the port.
+func clientAddress(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// FMCSubmitHandler scores the solution of an attempt and stores the result.
+// A solution that breaks the rules is stored as a DNF. Every attempt can be
+// submitted once.
+func (fm *FMCManager) FMCSubmitHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req fmcSubmitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validators.ValidateAttempt(req.Attempt); err != nil {
+		respondWithValidationError(w, []ValidationError{{
+			Field:   "attempt",
+			Message: err.Error(),
+		}})
+		return
+	}
+
+	fm.mutex.Lock()
+	attempt, ok := fm.attempts.get(req.Attempt)
+	fm.attempts.delete(req.Attempt)
+	fm.mutex.Unlock()
+
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown attempt: %s", req.Attempt), http.StatusNotFound)
+		return
+	}
+
+	score := attempt.rules.Score(attempt.scramble, req.Solution, time.Since(attempt.startedAt))
+	result, err := fm.store.Add(store.FMCResult{
+		Attempt:  req.Attempt,
+		Name:     attempt.name,
+		Scramble: attempt.scramble,
+		Rules:    attempt.rules,
+		FMCScore: score,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"result":  result,
+	})
+}
+
+// FMCResultsHandler lists the stored results, optionally of one player.
+func (fm *FMCManager) FMCResultsHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"results": fm.store.List(r.URL.Query().Get("name")),
+	})
+}
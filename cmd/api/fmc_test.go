@@ -0,0 +1,269 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/store"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newTestFMCManager(t *testing.T) *FMCManager {
+	s, err := store.NewFMCStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return NewFMCManager(s)
+}
+
+// TestFMCAttempt runs two attempts through the FMC endpoints
+func TestFMCAttempt(t *testing.T) {
+	fm := newTestFMCManager(t)
+
+	send := func(handler http.HandlerFunc, method, url, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, url, bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	start := func(body string) (string, models.Algorithm, models.FMCRules) {
+		rr := send(fm.FMCStartHandler, "POST", "/api/fmc/start", body)
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+		}
+		var started struct {
+			Attempt  string           `json:"attempt"`
+			Scramble models.Algorithm `json:"scramble"`
+			Rules    models.FMCRules  `json:"rules"`
+		}
+		json.Unmarshal(rr.Body.Bytes(), &started)
+		return started.Attempt, started.Scramble, started.Rules
+	}
+
+	var submitted struct {
+		Result store.FMCResult `json:"result"`
+	}
+
+	// Step 1: Start an attempt under the WCA rules and solve it with eight
+	// more moves than the scramble
+	attempt, scramble, rules := start(`{"name":"Ana"}`)
+	if rules != models.DefaultFMCRules() {
+		t.Errorf("Expected the WCA rules, got %+v", rules)
+	}
+	extra, _ := models.ParseAlgorithm("R2 D2 U2 L2 R2 D2 U2 L2")
+	solution, _ := append(scramble.Inverse(), extra...).MarshalText()
+	rr := send(fm.FMCSubmitHandler, "POST", "/api/fmc/submit", `{"attempt":"`+attempt+`","solution":"`+string(solution)+`"}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	json.Unmarshal(rr.Body.Bytes(), &submitted)
+	if submitted.Result.DNF || submitted.Result.Moves != len(scramble)+8 || submitted.Result.Name != "Ana" {
+		t.Errorf("Unexpected result: %s", rr.Body.String())
+	}
+
+	// Step 2: An attempt is submitted only once
+	rr = send(fm.FMCSubmitHandler, "POST", "/api/fmc/submit", `{"attempt":"`+attempt+`","solution":"R"}`)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, rr.Code)
+	}
+
+	// Step 3: A slice turn is a DNF with slices forbidden
+	attempt, _, _ = start(`{"name":"Boris","timeLimit":600}`)
+	rr = send(fm.FMCSubmitHandler, "POST", "/api/fmc/submit", `{"attempt":"`+attempt+`","solution":"R M"}`)
+	json.Unmarshal(rr.Body.Bytes(), &submitted)
+	if !submitted.Result.DNF || submitted.Result.Reason != "slice turn M at move 2 is not allowed" {
+		t.Errorf("Expected a DNF for the slice turn, got %s", rr.Body.String())
+	}
+
+	// Step 4: Undoing the scramble held the other way round is a DNF
+	attempt, scramble, _ = start(`{"name":"Cleo"}`)
+	turned := map[string]string{"U": "U", "D": "D", "F": "B", "B": "F", "R": "L", "L": "R"}
+	rotated := models.Algorithm{{Face: "y", Amount: 2}}
+	for _, turn := range scramble.Inverse() {
+		rotated = append(rotated, models.Turn{Face: turned[turn.Face], Amount: turn.Amount})
+	}
+	solution, _ = rotated.MarshalText()
+	rr = send(fm.FMCSubmitHandler, "POST", "/api/fmc/submit", `{"attempt":"`+attempt+`","solution":"`+string(solution)+`"}`)
+	json.Unmarshal(rr.Body.Bytes(), &submitted)
+	if !submitted.Result.DNF || submitted.Result.Reason != "solution is the inverse of the scramble" {
+		t.Errorf("Expected a DNF for the inverse, got %s", rr.Body.String())
+	}
+
+	// Step 5: All results are stored
+	rr = send(fm.FMCResultsHandler, "GET", "/api/fmc/results", "")
+	var listed struct {
+		Results []store.FMCResult `json:"results"`
+	}
+	json.Unmarshal(rr.Body.Bytes(), &listed)
+	if len(listed.Results) != 3 || listed.Results[1].Rules.TimeLimit != 600 {
+		t.Errorf("Unexpected results: %s", rr.Body.String())
+	}
+}
+
+// TestFMCAttemptExpires checks that an attempt never submitted is dropped
+func TestFMCAttemptExpires(t *testing.T) {
+	fm := newTestFMCManager(t)
+	now := time.Now()
+	fm.attempts.now = func() time.Time { return now }
+
+	// Step 1: Start an attempt
+	req, _ := http.NewRequest("POST", "/api/fmc/start", bytes.NewBufferString(`{"name":"Ana"}`))
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(fm.FMCStartHandler).ServeHTTP(rr, req)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+	var started struct {
+		Attempt string `json:"attempt"`
+	}
+	json.Unmarshal(rr.Body.Bytes(), &started)
+
+	// Step 2: Long after the longest time limit the attempt is unknown
+	now = now.Add(fmcAttemptTTL + time.Minute)
+	req, _ = http.NewRequest("POST", "/api/fmc/submit", bytes.NewBufferString(`{"attempt":"`+started.Attempt+`","solution":"R"}`))
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(fm.FMCSubmitHandler).ServeHTTP(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, rr.Code)
+	}
+}
+
+// TestFMCAttemptLimit checks that one client cannot hold more than a few
+// open attempts
+func TestFMCAttemptLimit(t *testing.T) {
+	fm := newTestFMCManager(t)
+
+	start := func(address string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/fmc/start", bytes.NewBufferString(`{"name":"Ana"}`))
+		req.RemoteAddr = address
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fm.FMCStartHandler).ServeHTTP(rr, req)
+		return rr
+	}
+
+	// Step 1: A client can open attempts up to the limit, from any port
+	var started struct {
+		Attempt string `json:"attempt"`
+	}
+	for i := 0; i < fmcAttemptsPerClient; i++ {
+		rr := start(fmt.Sprintf("192.0.2.1:%d", 40000+i))
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+		}
+		json.Unmarshal(rr.Body.Bytes(), &started)
+	}
+
+	// Step 2: One more is refused
+	rr := start("192.0.2.1:41000")
+	if rr.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected status %d, got %d", http.StatusTooManyRequests, rr.Code)
+	}
+
+	// Step 3: Another client is not affected
+	rr = start("198.51.100.7:40000")
+	if rr.Code != http.StatusCreated {
+		t.Errorf("Expected status %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+
+	// Step 4: Submitting an attempt frees a place
+	req := httptest.NewRequest("POST", "/api/fmc/submit", bytes.NewBufferString(`{"attempt":"`+started.Attempt+`","solution":"R"}`))
+	http.HandlerFunc(fm.FMCSubmitHandler).ServeHTTP(httptest.NewRecorder(), req)
+	rr = start("192.0.2.1:41000")
+	if rr.Code != http.StatusCreated {
+		t.Errorf("Expected status %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+}
+
+// TestFMCErrors tests the validation of the FMC endpoints
+func TestFMCErrors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		handler        string
+		method         string
+		body           string
+		expectedStatus int
+		expectedErrors []ValidationError
+	}{
+		{
+			name:           "Invalid Start",
+			handler:        "start",
+			method:         "POST",
+			body:           `{"timeLimit":0}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "name",
+					Message: "name cannot be empty",
+				},
+				{
+					Field:   "timeLimit",
+					Message: "timeLimit must be between 1 and 86400 seconds",
+				},
+			},
+		},
+		{
+			name:           "Missing Attempt",
+			handler:        "submit",
+			method:         "POST",
+			body:           `{"solution":"R"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "attempt",
+					Message: "attempt cannot be empty",
+				},
+			},
+		},
+		{
+			name:           "Unknown Attempt",
+			handler:        "submit",
+			method:         "POST",
+			body:           `{"attempt":"missing","solution":"R"}`,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Method Not Allowed",
+			handler:        "results",
+			method:         "POST",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fm := newTestFMCManager(t)
+			handlers := map[string]http.HandlerFunc{
+				"start":   fm.FMCStartHandler,
+				"submit":  fm.FMCSubmitHandler,
+				"results": fm.FMCResultsHandler,
+			}
+
+			req, _ := http.NewRequest(tc.method, "/api/fmc/"+tc.handler, bytes.NewBufferString(tc.body))
+			rr := httptest.NewRecorder()
+			handlers[tc.handler].ServeHTTP(rr, req)
+
+			// Check the status code
+			if status := rr.Code; status != tc.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tc.expectedStatus)
+			}
+
+			if tc.expectedErrors != nil {
+				var response ValidationResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to unmarshal error response: %v", err)
+				}
+
+				if !reflect.DeepEqual(response.Errors, tc.expectedErrors) {
+					t.Errorf("Error mismatch:\nExpected: %+v\nActual: %+v", tc.expectedErrors, response.Errors)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// HintHandler tells a learner which beginner stage the current cube is in
+// and suggests the moves that solve the next piece.
+func (cm *CubeManager) HintHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	cm.mutex.RLock()
+	cube := *cm.cube
+	cm.mutex.RUnlock()
+
+	ctx, cancel := context.WithTimeout(r.Context(), solveTimeout)
+	defer cancel()
+
+	hint, err := cube.Hint(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		http.Error(w, "solver timed out", http.StatusServiceUnavailable)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"hint":    hint,
+	})
+}
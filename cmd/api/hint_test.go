@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestHintHandler tests the HintHandler function
+func TestHintHandler(t *testing.T) {
+	testCases := []struct {
+		name              string
+		method            string
+		setup             string
+		expectedStatus    int
+		expectedStage     string
+		expectedAlgorithm string
+	}{
+		{
+			name:              "Solved Cube",
+			method:            "GET",
+			expectedStatus:    http.StatusOK,
+			expectedStage:     models.StageSolved,
+			expectedAlgorithm: "",
+		},
+		{
+			name:              "Broken Cross",
+			method:            "GET",
+			setup:             "F",
+			expectedStatus:    http.StatusOK,
+			expectedStage:     models.StageCross,
+			expectedAlgorithm: "F'",
+		},
+		{
+			name:              "Top Layer Turned",
+			method:            "GET",
+			setup:             "U2",
+			expectedStatus:    http.StatusOK,
+			expectedStage:     models.StagePermuteEdges,
+			expectedAlgorithm: "U2",
+		},
+		{
+			name:           "Method Not Allowed",
+			method:         "POST",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cm := NewCubeManager()
+			if tc.setup != "" {
+				cm.cube.ApplyAlgorithm(tc.setup)
+			}
+			before := *cm.cube
+
+			req, _ := http.NewRequest(tc.method, "/api/cube/hint", nil)
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(cm.HintHandler)
+
+			handler.ServeHTTP(rr, req)
+
+			// Check the status code
+			if status := rr.Code; status != tc.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tc.expectedStatus)
+			}
+
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Success bool        `json:"success"`
+				Hint    models.Hint `json:"hint"`
+			}
+			if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+
+			if response.Hint.Stage != tc.expectedStage {
+				t.Errorf("Expected stage %s, got %s", tc.expectedStage, response.Hint.Stage)
+			}
+			if response.Hint.Algorithm.String() != tc.expectedAlgorithm {
+				t.Errorf("Expected hint %q, got %q", tc.expectedAlgorithm, response.Hint.Algorithm.String())
+			}
+			if response.Hint.Explanation == "" {
+				t.Errorf("Expected an explanation")
+			}
+
+			// A hint must not change the cube
+			if *cm.cube != before {
+				t.Errorf("Expected the cube to be left unchanged")
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestInverseHandler tests the inverse state of the managed cube
+func TestInverseHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		scramble       string
+		expectedStatus int
+		expectedState  string
+	}{
+		{
+			name:           "Inverse Scramble",
+			method:         "GET",
+			scramble:       "R U F' D2",
+			expectedStatus: http.StatusOK,
+			expectedState:  "D2 F U' R'",
+		},
+		{
+			name:           "Rotations",
+			method:         "GET",
+			scramble:       "R x U",
+			expectedStatus: http.StatusOK,
+			expectedState:  "U' x' R'",
+		},
+		{
+			name:           "Method Not Allowed",
+			method:         "POST",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cm := NewCubeManager()
+			cm.cube.ApplyAlgorithm(tc.scramble)
+			scrambled := *cm.cube
+
+			req, _ := http.NewRequest(tc.method, "/api/cube/inverse", nil)
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(cm.InverseHandler)
+
+			handler.ServeHTTP(rr, req)
+
+			// Check the status code
+			if status := rr.Code; status != tc.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tc.expectedStatus)
+			}
+
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Success bool              `json:"success"`
+				Cube    models.RubiksCube `json:"cube"`
+			}
+			if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+
+			expected := models.New()
+			expected.ApplyAlgorithm(tc.expectedState)
+			if response.Cube != *expected {
+				t.Errorf("Expected the state of %s", tc.expectedState)
+			}
+			if *cm.cube != scrambled {
+				t.Errorf("Expected the managed cube to stay scrambled")
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestLastLayerHandler tests the LastLayerHandler function
+func TestLastLayerHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		setup          string
+		expectedStatus int
+		expectedCase   models.LastLayerCase
+		expectedError  string
+	}{
+		{
+			name:           "Sune",
+			method:         "GET",
+			setup:          "R U2 R' U' R U' R'",
+			expectedStatus: http.StatusOK,
+			expectedCase:   models.LastLayerCase{Stage: "oll", OLL: 27, Subset: "S"},
+		},
+		{
+			name:           "T Perm With AUF",
+			method:         "GET",
+			setup:          "R U R' U' R' F R2 U' R' U' R U R' F' U",
+			expectedStatus: http.StatusOK,
+			expectedCase:   models.LastLayerCase{Stage: "pll", PLL: "T", PreAUF: "U'"},
+		},
+		{
+			name:           "Solved",
+			method:         "GET",
+			expectedStatus: http.StatusOK,
+			expectedCase:   models.LastLayerCase{Stage: "solved"},
+		},
+		{
+			name:           "F2L Not Solved",
+			method:         "GET",
+			setup:          "R U R'",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "first two layers are not solved",
+		},
+		{
+			name:           "Method Not Allowed",
+			method:         "POST",
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedError:  "Method not allowed",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cm := NewCubeManager()
+			if tc.setup != "" {
+				cm.cube.ApplyAlgorithm(tc.setup)
+			}
+
+			req, _ := http.NewRequest(tc.method, "/api/cube/last-layer", nil)
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(cm.LastLayerHandler)
+			handler.ServeHTTP(rr, req)
+
+			// Check the status code
+			if status := rr.Code; status != tc.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tc.expectedStatus)
+			}
+
+			// For error cases, verify the error message
+			if tc.expectedError != "" {
+				if body := strings.TrimSpace(rr.Body.String()); body != tc.expectedError {
+					t.Errorf("Expected error %q, got %q", tc.expectedError, body)
+				}
+				return
+			}
+
+			var response struct {
+				Success   bool                 `json:"success"`
+				LastLayer models.LastLayerCase `json:"lastLayer"`
+			}
+			if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal success response: %v", err)
+			}
+
+			if !response.Success || !reflect.DeepEqual(response.LastLayer, tc.expectedCase) {
+				t.Errorf("Expected case %+v, got %+v", tc.expectedCase, response.LastLayer)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/validators"
+	"net/http"
+	"sync"
+)
+
+// LessonManager keeps the lesson progress of every session in memory,
+// until the session has gone unused for sessionTTL.
+type LessonManager struct {
+	sessions *sessionStore[*models.LessonProgress]
+	mutex    sync.Mutex
+}
+
+func NewLessonManager() *LessonManager {
+	return &LessonManager{
+		sessions: newSessionStore[*models.LessonProgress](sessionTTL, sessionLimit),
+	}
+}
+
+type lessonSessionRequest struct {
+	Session string `json:"session"`
+	Lesson  string `json:"lesson"`
+}
+
+type lessonMoveRequest struct {
+	Session string `json:"session"`
+	Moves   string `json:"moves"`
+}
+
+// LessonsHandler lists the lessons in the order they are meant to be taken.
+func LessonsHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"lessons": models.Lessons(),
+	})
+}
+
+// SessionHandler returns the progress of a session on GET. POST starts a
+// lesson, the first one by default, in the given session or in a new one.
+func (lm *LessonManager) SessionHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		session := r.URL.Query().Get("session")
+		if err := validators.ValidateSession(session); err != nil {
+			respondWithValidationError(w, []ValidationError{{
+				Field:   "session",
+				Message: err.Error(),
+			}})
+			return
+		}
+
+		lm.mutex.Lock()
+		defer lm.mutex.Unlock()
+
+		progress, ok := lm.sessions.get(session)
+		if !ok {
+			http.Error(w, fmt.Sprintf("unknown session: %s", session), http.StatusNotFound)
+			return
+		}
+		respondWithProgress(w, http.StatusOK, session, progress)
+	case http.MethodPost:
+		lm.startLesson(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (lm *LessonManager) startLesson(w http.ResponseWriter, r *http.Request) {
+	var req lessonSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Lesson != "" {
+		if err := validators.ValidateLesson(req.Lesson); err != nil {
+			respondWithValidationError(w, []ValidationError{{
+				Field:   "lesson",
+				Message: err.Error(),
+			}})
+			return
+		}
+	}
+
+	lm.mutex.Lock()
+	defer lm.mutex.Unlock()
+
+	status := http.StatusOK
+	progress, ok := lm.sessions.get(req.Session)
+	if req.Session == "" {
+		req.Session = newSessionID()
+		progress = models.NewLessonProgress()
+		lm.sessions.put(req.Session, progress)
+		status = http.StatusCreated
+	} else if !ok {
+		http.Error(w, fmt.Sprintf("unknown session: %s", req.Session), http.StatusNotFound)
+		return
+	}
+
+	if req.Lesson != "" {
+		progress.Start(req.Lesson)
+	}
+
+	respondWithProgress(w, status, req.Session, progress)
+}
+
+// LessonMoveHandler performs moves in the current lesson of a session and
+// moves on to the next lesson once the goal is reached.
+func (lm *LessonManager) LessonMoveHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req lessonMoveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	var validationErrors []ValidationError
+	if err := validators.ValidateSession(req.Session); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "session",
+			Message: err.Error(),
+		})
+	}
+
+	if err := validators.ValidateAlgorithm(req.Moves); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "moves",
+			Message: err.Error(),
+		})
+	}
+
+	if len(validationErrors) > 0 {
+		respondWithValidationError(w, validationErrors)
+		return
+	}
+
+	alg, _ := models.ParseAlgorithm(req.Moves)
+
+	lm.mutex.Lock()
+	defer lm.mutex.Unlock()
+
+	progress, ok := lm.sessions.get(req.Session)
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown session: %s", req.Session), http.StatusNotFound)
+		return
+	}
+
+	lesson := progress.Lesson
+	reached, err := progress.Move(alg)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":     true,
+		"session":     req.Session,
+		"lesson":      lesson,
+		"goalReached": reached,
+		"progress":    progress,
+	})
+}
+
+func respondWithProgress(w http.ResponseWriter, status int, session string, progress *models.LessonProgress) {
+	lesson, _ := models.FindLesson(progress.Lesson)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"session":  session,
+		"current":  lesson,
+		"progress": progress,
+	})
+}
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// TestLessonsHandler tests the LessonsHandler function
+func TestLessonsHandler(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/api/lessons", nil)
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(LessonsHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	var response struct {
+		Success bool            `json:"success"`
+		Lessons []models.Lesson `json:"lessons"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if len(response.Lessons) != len(models.Lessons()) {
+		t.Errorf("Expected %d lessons, got %d", len(models.Lessons()), len(response.Lessons))
+	}
+	if response.Lessons[0].ID != "cross-edge" {
+		t.Errorf("Expected cross-edge first, got %s", response.Lessons[0].ID)
+	}
+}
+
+type lessonResponse struct {
+	Success     bool   `json:"success"`
+	Session     string `json:"session"`
+	Lesson      string `json:"lesson"`
+	GoalReached bool   `json:"goalReached"`
+	Progress    struct {
+		Lesson    string   `json:"lesson"`
+		Moves     string   `json:"moves"`
+		Completed []string `json:"completed"`
+		Finished  bool     `json:"finished"`
+	} `json:"progress"`
+}
+
+// TestLessonSession walks a session through the first lesson
+func TestLessonSession(t *testing.T) {
+	lm := NewLessonManager()
+
+	send := func(handler http.HandlerFunc, method, url, body string) (*httptest.ResponseRecorder, lessonResponse) {
+		req, _ := http.NewRequest(method, url, bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		var response lessonResponse
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		return rr, response
+	}
+
+	// Step 1: Start a new session
+	rr, created := send(lm.SessionHandler, "POST", "/api/lessons/session", `{}`)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d", http.StatusCreated, rr.Code)
+	}
+	if created.Session == "" || created.Progress.Lesson != "cross-edge" {
+		t.Fatalf("Expected a session on cross-edge, got %+v", created)
+	}
+	session := created.Session
+
+	// Step 2: A move that does not solve the lesson
+	rr, moved := send(lm.LessonMoveHandler, "POST", "/api/lessons/move", `{"session":"`+session+`","moves":"U"}`)
+	if rr.Code != http.StatusOK || moved.GoalReached {
+		t.Fatalf("Expected U not to reach the goal, got %d %+v", rr.Code, moved)
+	}
+
+	// Step 3: Solving the lesson moves on to the next one
+	rr, moved = send(lm.LessonMoveHandler, "POST", "/api/lessons/move", `{"session":"`+session+`","moves":"F2"}`)
+	if rr.Code != http.StatusOK || !moved.GoalReached {
+		t.Fatalf("Expected F2 to reach the goal, got %d %+v", rr.Code, moved)
+	}
+	if moved.Lesson != "cross-edge" || moved.Progress.Lesson != "first-layer-corner" {
+		t.Errorf("Expected to move from cross-edge to first-layer-corner, got %s to %s", moved.Lesson, moved.Progress.Lesson)
+	}
+
+	// Step 4: The progress is kept for the session
+	rr, current := send(lm.SessionHandler, "GET", "/api/lessons/session?session="+session, "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	if !reflect.DeepEqual(current.Progress.Completed, []string{"cross-edge"}) {
+		t.Errorf("Expected cross-edge to be completed, got %v", current.Progress.Completed)
+	}
+
+	// Step 5: Jump to another lesson
+	rr, started := send(lm.SessionHandler, "POST", "/api/lessons/session", `{"session":"`+session+`","lesson":"permute-edges"}`)
+	if rr.Code != http.StatusOK || started.Progress.Lesson != "permute-edges" {
+		t.Errorf("Expected to start permute-edges, got %d %+v", rr.Code, started)
+	}
+
+	// Step 6: A new session starts from the beginning
+	_, other := send(lm.SessionHandler, "POST", "/api/lessons/session", `{}`)
+	if other.Session == session || other.Progress.Lesson != "cross-edge" {
+		t.Errorf("Expected a separate session on cross-edge, got %+v", other)
+	}
+}
+
+// TestLessonSessionErrors tests the validation of the lesson endpoints
+func TestLessonSessionErrors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		move           bool
+		method         string
+		url            string
+		body           string
+		expectedStatus int
+		expectedErrors []ValidationError
+	}{
+		{
+			name:           "Missing Session",
+			method:         "GET",
+			url:            "/api/lessons/session",
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "session",
+					Message: "session cannot be empty",
+				},
+			},
+		},
+		{
+			name:           "Unknown Session",
+			method:         "GET",
+			url:            "/api/lessons/session?session=missing",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Unknown Lesson",
+			method:         "POST",
+			url:            "/api/lessons/session",
+			body:           `{"lesson":"f2l"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "lesson",
+					Message: "unknown lesson: f2l",
+				},
+			},
+		},
+		{
+			name:           "Invalid Move",
+			move:           true,
+			method:         "POST",
+			url:            "/api/lessons/move",
+			body:           `{"moves":"Q"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "session",
+					Message: "session cannot be empty",
+				},
+				{
+					Field:   "moves",
+					Message: "invalid algorithm: invalid move \"Q\" at position 0",
+				},
+			},
+		},
+		{
+			name:           "Move In Unknown Session",
+			move:           true,
+			method:         "POST",
+			url:            "/api/lessons/move",
+			body:           `{"session":"missing","moves":"U"}`,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Method Not Allowed",
+			move:           true,
+			method:         "GET",
+			url:            "/api/lessons/move",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lm := NewLessonManager()
+			handler := http.HandlerFunc(lm.SessionHandler)
+			if tc.move {
+				handler = lm.LessonMoveHandler
+			}
+
+			req, _ := http.NewRequest(tc.method, tc.url, bytes.NewBufferString(tc.body))
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			// Check the status code
+			if status := rr.Code; status != tc.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tc.expectedStatus)
+			}
+
+			if tc.expectedErrors != nil {
+				var response ValidationResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to unmarshal error response: %v", err)
+				}
+
+				if !reflect.DeepEqual(response.Errors, tc.expectedErrors) {
+					t.Errorf("Error mismatch:\nExpected: %+v\nActual: %+v", tc.expectedErrors, response.Errors)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/validators"
+	"net/http"
+)
+
+type categorySummary struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Cases       int    `json:"cases"`
+}
+
+// LibraryHandler lists the categories of the built-in algorithm library, or
+// the cases of one category when it is given as a query parameter.
+func LibraryHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := r.URL.Query().Get("category")
+	if id == "" {
+		var categories []categorySummary
+		for _, category := range models.Library() {
+			categories = append(categories, categorySummary{
+				ID:          category.ID,
+				Name:        category.Name,
+				Description: category.Description,
+				Cases:       len(category.Cases),
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success":    true,
+			"categories": categories,
+		})
+		return
+	}
+
+	var validationErrors []ValidationError
+	if err := validators.ValidateLibraryCategory(id); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "category",
+			Message: err.Error(),
+		})
+	}
+
+	if len(validationErrors) > 0 {
+		respondWithValidationError(w, validationErrors)
+		return
+	}
+
+	category, _ := models.FindLibraryCategory(id)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"category": category,
+	})
+}
+
+func LibraryCaseHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+
+	var validationErrors []ValidationError
+	if err := validators.ValidateLibraryCase(id); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "id",
+			Message: err.Error(),
+		})
+	}
+
+	if len(validationErrors) > 0 {
+		respondWithValidationError(w, validationErrors)
+		return
+	}
+
+	c, _ := models.FindLibraryCase(id)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"case":    c,
+	})
+}
+
+type libraryApplyRequest struct {
+	Case      string `json:"case"`
+	Algorithm int    `json:"algorithm"`
+}
+
+// LibraryApplyHandler applies one of the algorithms of a library case to the
+// managed cube.
+func (cm *CubeManager) LibraryApplyHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req libraryApplyRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	var validationErrors []ValidationError
+	if err := validators.ValidateLibraryCase(req.Case); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "case",
+			Message: err.Error(),
+		})
+	} else {
+		c, _ := models.FindLibraryCase(req.Case)
+		if err := validators.ValidateAlgorithmIndex(req.Algorithm, len(c.Algorithms)); err != nil {
+			validationErrors = append(validationErrors, ValidationError{
+				Field:   "algorithm",
+				Message: err.Error(),
+			})
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		respondWithValidationError(w, validationErrors)
+		return
+	}
+
+	c, _ := models.FindLibraryCase(req.Case)
+	alg := c.Algorithms[req.Algorithm]
+
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	cm.cube.Apply(alg)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":   true,
+		"case":      c.Name,
+		"algorithm": alg.String(),
+		"cube":      cm.cube,
+	})
+}
+
+// MatchingAlgorithmsHandler lists the library algorithms that solve the
+// current cube, with the rotation and AUFs they need.
+func (cm *CubeManager) MatchingAlgorithmsHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
+
+	matches, err := cm.cube.MatchingAlgorithms()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"matches": matches,
+	})
+}
@@ -0,0 +1,321 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// TestLibraryHandler tests the LibraryHandler function
+func TestLibraryHandler(t *testing.T) {
+	// List the categories
+	req, _ := http.NewRequest("GET", "/api/library", nil)
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(LibraryHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	var list struct {
+		Success    bool              `json:"success"`
+		Categories []categorySummary `json:"categories"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &list); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	counts := make(map[string]int)
+	for _, category := range list.Categories {
+		counts[category.ID] = category.Cases
+	}
+	if counts["oll"] != 57 || counts["pll"] != 21 || counts["f2l"] != 41 {
+		t.Errorf("Unexpected category counts: %v", counts)
+	}
+
+	// Fetch one category
+	req, _ = http.NewRequest("GET", "/api/library?category=pll", nil)
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(LibraryHandler).ServeHTTP(rr, req)
+
+	var category struct {
+		Success  bool                   `json:"success"`
+		Category models.LibraryCategory `json:"category"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &category); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if category.Category.ID != "pll" || len(category.Category.Cases) != 21 {
+		t.Errorf("Expected the PLL category, got %s with %d cases", category.Category.ID, len(category.Category.Cases))
+	}
+
+	// Unknown category
+	req, _ = http.NewRequest("GET", "/api/library?category=zbll", nil)
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(LibraryHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
+}
+
+// TestLibraryCaseHandler tests the LibraryCaseHandler function
+func TestLibraryCaseHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		url            string
+		expectedStatus int
+		expectedName   string
+		expectedErrors []ValidationError
+	}{
+		{
+			name:           "OLL Case",
+			url:            "/api/library/case?id=oll-27",
+			expectedStatus: http.StatusOK,
+			expectedName:   "OLL 27",
+		},
+		{
+			name:           "Missing ID",
+			url:            "/api/library/case",
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "id",
+					Message: "case cannot be empty",
+				},
+			},
+		},
+		{
+			name:           "Unknown Case",
+			url:            "/api/library/case?id=oll-58",
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "id",
+					Message: "unknown case: oll-58",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tc.url, nil)
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(LibraryCaseHandler).ServeHTTP(rr, req)
+
+			// Check the status code
+			if status := rr.Code; status != tc.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tc.expectedStatus)
+			}
+
+			// For error cases, verify the error response
+			if tc.expectedErrors != nil {
+				var response ValidationResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to unmarshal error response: %v", err)
+				}
+
+				if !reflect.DeepEqual(response.Errors, tc.expectedErrors) {
+					t.Errorf("Error mismatch:\nExpected: %+v\nActual: %+v", tc.expectedErrors, response.Errors)
+				}
+				return
+			}
+
+			var response struct {
+				Success bool               `json:"success"`
+				Case    models.LibraryCase `json:"case"`
+			}
+			if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal success response: %v", err)
+			}
+
+			if response.Case.Name != tc.expectedName || len(response.Case.Algorithms) == 0 {
+				t.Errorf("Expected case %s with algorithms, got %+v", tc.expectedName, response.Case)
+			}
+		})
+	}
+}
+
+// TestLibraryApplyHandler tests the LibraryApplyHandler function
+func TestLibraryApplyHandler(t *testing.T) {
+	testCases := []struct {
+		name              string
+		requestBody       map[string]interface{}
+		expectedStatus    int
+		expectedAlgorithm string
+		expectedErrors    []ValidationError
+	}{
+		{
+			name: "First Algorithm",
+			requestBody: map[string]interface{}{
+				"case": "pll-t",
+			},
+			expectedStatus:    http.StatusOK,
+			expectedAlgorithm: "R U R' U' R' F R2 U' R' U' R U R' F'",
+		},
+		{
+			name: "Alternative Algorithm",
+			requestBody: map[string]interface{}{
+				"case":      "pll-ua",
+				"algorithm": 1,
+			},
+			expectedStatus:    http.StatusOK,
+			expectedAlgorithm: "R U' R U R U R U' R' U' R2",
+		},
+		{
+			name: "Algorithm Out Of Range",
+			requestBody: map[string]interface{}{
+				"case":      "pll-t",
+				"algorithm": 3,
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "algorithm",
+					Message: "algorithm index must be between 0 and 0",
+				},
+			},
+		},
+		{
+			name: "Unknown Case",
+			requestBody: map[string]interface{}{
+				"case": "pll-x",
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "case",
+					Message: "unknown case: pll-x",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cm := NewCubeManager()
+
+			body, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest("POST", "/api/library/apply", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(cm.LibraryApplyHandler)
+			handler.ServeHTTP(rr, req)
+
+			// Check the status code
+			if status := rr.Code; status != tc.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tc.expectedStatus)
+			}
+
+			// For error cases, verify the error response
+			if tc.expectedErrors != nil {
+				var response ValidationResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to unmarshal error response: %v", err)
+				}
+
+				if !reflect.DeepEqual(response.Errors, tc.expectedErrors) {
+					t.Errorf("Error mismatch:\nExpected: %+v\nActual: %+v", tc.expectedErrors, response.Errors)
+				}
+				return
+			}
+
+			// Verify the managed cube received the algorithm
+			expected := models.New()
+			expected.ApplyAlgorithm(tc.expectedAlgorithm)
+			if !reflect.DeepEqual(*cm.cube, *expected) {
+				t.Errorf("Managed cube does not match %s", tc.expectedAlgorithm)
+			}
+		})
+	}
+}
+
+// TestMatchingAlgorithmsHandler tests the MatchingAlgorithmsHandler function
+func TestMatchingAlgorithmsHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		setup          string
+		expectedStatus int
+		expectedCases  []string
+		expectedPreAUF string
+	}{
+		{
+			name:           "Sune With AUF",
+			method:         "GET",
+			setup:          "R U2 R' U' R U' R' U",
+			expectedStatus: http.StatusOK,
+			expectedCases:  []string{"oll-27", "ocll-sune"},
+			expectedPreAUF: "U'",
+		},
+		{
+			name:           "T Perm",
+			method:         "GET",
+			setup:          "R U R' U' R' F R2 U' R' U' R U R' F'",
+			expectedStatus: http.StatusOK,
+			expectedCases:  []string{"pll-t", "cpll-headlights"},
+		},
+		{
+			name:           "Solved",
+			method:         "GET",
+			expectedStatus: http.StatusOK,
+			expectedCases:  []string{},
+		},
+		{
+			name:           "Method Not Allowed",
+			method:         "POST",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cm := NewCubeManager()
+			if tc.setup != "" {
+				cm.cube.ApplyAlgorithm(tc.setup)
+			}
+
+			req, _ := http.NewRequest(tc.method, "/api/cube/matching-algorithms", nil)
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(cm.MatchingAlgorithmsHandler)
+			handler.ServeHTTP(rr, req)
+
+			// Check the status code
+			if status := rr.Code; status != tc.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tc.expectedStatus)
+			}
+
+			if tc.expectedCases == nil {
+				return
+			}
+
+			var response struct {
+				Success bool                    `json:"success"`
+				Matches []models.AlgorithmMatch `json:"matches"`
+			}
+			if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+
+			cases := []string{}
+			for _, match := range response.Matches {
+				cases = append(cases, match.Case)
+				if match.PreAUF != tc.expectedPreAUF {
+					t.Errorf("Expected pre-AUF %q for %s, got %q", tc.expectedPreAUF, match.Case, match.PreAUF)
+				}
+			}
+			if !reflect.DeepEqual(cases, tc.expectedCases) {
+				t.Errorf("Expected cases %v, got %v", tc.expectedCases, cases)
+			}
+		})
+	}
+}
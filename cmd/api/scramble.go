@@ -0,0 +1,110 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/validators"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ScrambleHandler returns a uniformly random state of a subset, the whole
+// cube by default, with a scramble that reaches it from a solved cube.
+// Optional constraints reject states that would make the solve too easy.
+func ScrambleHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	subset := query.Get("subset")
+	if subset == "" {
+		subset = models.SubsetFull
+	}
+
+	var validationErrors []ValidationError
+	if err := validators.ValidateScrambleSubset(subset); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "subset",
+			Message: err.Error(),
+		})
+	}
+
+	var constraints models.ScrambleConstraints
+	if value := query.Get("minCrossLength"); value != "" {
+		length, err := strconv.Atoi(value)
+		if err != nil {
+			validationErrors = append(validationErrors, ValidationError{
+				Field:   "minCrossLength",
+				Message: "minCrossLength must be a number",
+			})
+		} else if err := validators.ValidateMinCrossLength(length); err != nil {
+			validationErrors = append(validationErrors, ValidationError{
+				Field:   "minCrossLength",
+				Message: err.Error(),
+			})
+		}
+		constraints.MinCrossLength = length
+	}
+
+	flags := []struct {
+		field string
+		value *bool
+	}{
+		{"noSolvedPairs", &constraints.NoSolvedPairs},
+		{"noOrientedLastLayer", &constraints.NoOrientedLastLayer},
+	}
+	for _, flag := range flags {
+		if value := query.Get(flag.field); value != "" {
+			set, err := strconv.ParseBool(value)
+			if err != nil {
+				validationErrors = append(validationErrors, ValidationError{
+					Field:   flag.field,
+					Message: flag.field + " must be true or false",
+				})
+			}
+			*flag.value = set
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		respondWithValidationError(w, validationErrors)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), solveTimeout)
+	defer cancel()
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	scramble, err := models.GenerateScramble(ctx, subset, constraints, rng)
+	if errors.Is(err, context.DeadlineExceeded) {
+		http.Error(w, "scramble generation timed out", http.StatusServiceUnavailable)
+		return
+	}
+	if errors.Is(err, models.ErrConstraintsNotMet) {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"scramble": scramble,
+	})
+}
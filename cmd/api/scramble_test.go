@@ -0,0 +1,135 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// TestScrambleHandler tests the ScrambleHandler function
+func TestScrambleHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		expectedStatus int
+		expectedSubset string
+		expectedErrors []ValidationError
+	}{
+		{
+			name:           "PLL",
+			method:         "GET",
+			url:            "/api/scramble?subset=pll",
+			expectedStatus: http.StatusOK,
+			expectedSubset: "pll",
+		},
+		{
+			name:           "LSE",
+			method:         "GET",
+			url:            "/api/scramble?subset=lse",
+			expectedStatus: http.StatusOK,
+			expectedSubset: "lse",
+		},
+		{
+			name:           "Last Layer Not Oriented",
+			method:         "GET",
+			url:            "/api/scramble?subset=ll&noOrientedLastLayer=true",
+			expectedStatus: http.StatusOK,
+			expectedSubset: "ll",
+		},
+		{
+			name:           "Constraints Not Met",
+			method:         "GET",
+			url:            "/api/scramble?subset=pll&noOrientedLastLayer=true",
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Invalid Constraints",
+			method:         "GET",
+			url:            "/api/scramble?minCrossLength=9&noSolvedPairs=maybe",
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "minCrossLength",
+					Message: "minCrossLength must be between 0 and 8",
+				},
+				{
+					Field:   "noSolvedPairs",
+					Message: "noSolvedPairs must be true or false",
+				},
+			},
+		},
+		{
+			name:           "Invalid Subset",
+			method:         "GET",
+			url:            "/api/scramble?subset=oll",
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "subset",
+					Message: "invalid subset: oll. Valid subsets are: full, ll, zbll, pll, lse, edges",
+				},
+			},
+		},
+		{
+			name:           "Method Not Allowed",
+			method:         "POST",
+			url:            "/api/scramble",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest(tc.method, tc.url, nil)
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(ScrambleHandler)
+
+			handler.ServeHTTP(rr, req)
+
+			// Check the status code
+			if status := rr.Code; status != tc.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tc.expectedStatus)
+			}
+
+			if tc.expectedErrors != nil {
+				var response ValidationResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to unmarshal error response: %v", err)
+				}
+
+				if !reflect.DeepEqual(response.Errors, tc.expectedErrors) {
+					t.Errorf("Error mismatch:\nExpected: %+v\nActual: %+v", tc.expectedErrors, response.Errors)
+				}
+				return
+			}
+
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Success  bool            `json:"success"`
+				Scramble models.Scramble `json:"scramble"`
+			}
+			if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+
+			if response.Scramble.Subset != tc.expectedSubset {
+				t.Errorf("Expected subset %s, got %s", tc.expectedSubset, response.Scramble.Subset)
+			}
+
+			// The scramble must lead to the cube that comes with it
+			cube := models.New()
+			cube.Apply(response.Scramble.Scramble)
+			if *cube != *response.Scramble.Cube {
+				t.Errorf("Scramble %s does not reach the returned cube", response.Scramble.Scramble)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+const (
+	// sessionTTL is how long a session is kept after its last use.
+	sessionTTL = 24 * time.Hour
+	// sessionLimit caps the sessions a manager keeps; when it is reached
+	// the least recently used session is dropped.
+	sessionLimit = 10000
+)
+
+// sessionStore keeps values by session ID until they have gone unused for
+// ttl, and at most limit of them. It does no locking of its own, so the
+// manager that owns it must hold its mutex.
+type sessionStore[T any] struct {
+	ttl     time.Duration
+	limit   int
+	now     func() time.Time
+	entries map[string]*sessionEntry[T]
+}
+
+type sessionEntry[T any] struct {
+	value    T
+	lastUsed time.Time
+}
+
+func newSessionStore[T any](ttl time.Duration, limit int) *sessionStore[T] {
+	return &sessionStore[T]{
+		ttl:     ttl,
+		limit:   limit,
+		now:     time.Now,
+		entries: make(map[string]*sessionEntry[T]),
+	}
+}
+
+// get returns the value of a session that has not expired and counts it as
+// used.
+func (s *sessionStore[T]) get(id string) (T, bool) {
+	entry, ok := s.entries[id]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+
+	now := s.now()
+	if now.Sub(entry.lastUsed) > s.ttl {
+		delete(s.entries, id)
+		var zero T
+		return zero, false
+	}
+	entry.lastUsed = now
+	return entry.value, true
+}
+
+// put stores the value of a session, dropping expired sessions and then the
+// least recently used one when the store is full.
+func (s *sessionStore[T]) put(id string, value T) {
+	now := s.now()
+	if _, ok := s.entries[id]; !ok && len(s.entries) >= s.limit {
+		s.removeExpired(now)
+	}
+	if _, ok := s.entries[id]; !ok && len(s.entries) >= s.limit {
+		s.removeOldest()
+	}
+	s.entries[id] = &sessionEntry[T]{value: value, lastUsed: now}
+}
+
+// count returns how many sessions that have not expired hold a value that
+// matches.
+func (s *sessionStore[T]) count(match func(T) bool) int {
+	now := s.now()
+	n := 0
+	for _, entry := range s.entries {
+		if now.Sub(entry.lastUsed) <= s.ttl && match(entry.value) {
+			n++
+		}
+	}
+	return n
+}
+
+func (s *sessionStore[T]) delete(id string) {
+	delete(s.entries, id)
+}
+
+func (s *sessionStore[T]) removeExpired(now time.Time) {
+	for id, entry := range s.entries {
+		if now.Sub(entry.lastUsed) > s.ttl {
+			delete(s.entries, id)
+		}
+	}
+}
+
+func (s *sessionStore[T]) removeOldest() {
+	oldest := ""
+	for id, entry := range s.entries {
+		if oldest == "" || entry.lastUsed.Before(s.entries[oldest].lastUsed) {
+			oldest = id
+		}
+	}
+	delete(s.entries, oldest)
+}
+
+func newSessionID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
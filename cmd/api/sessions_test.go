@@ -0,0 +1,66 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSessionStore checks that sessions expire when unused and that the
+// least recently used one goes when the store is full
+func TestSessionStore(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	s := newSessionStore[int](time.Hour, 2)
+	s.now = func() time.Time { return now }
+
+	// Step 1: A session is kept while it is used
+	s.put("a", 1)
+	now = now.Add(50 * time.Minute)
+	if value, ok := s.get("a"); !ok || value != 1 {
+		t.Fatalf("Expected session a to be kept, got %d %v", value, ok)
+	}
+	now = now.Add(50 * time.Minute)
+	if _, ok := s.get("a"); !ok {
+		t.Fatalf("Expected using session a to keep it")
+	}
+
+	// Step 2: A session expires after an hour without use
+	now = now.Add(61 * time.Minute)
+	if _, ok := s.get("a"); ok {
+		t.Errorf("Expected session a to expire")
+	}
+	if len(s.entries) != 0 {
+		t.Errorf("Expected the expired session to be removed, got %d", len(s.entries))
+	}
+
+	// Step 3: A full store drops the least recently used session
+	s.put("b", 2)
+	now = now.Add(time.Minute)
+	s.put("c", 3)
+	now = now.Add(time.Minute)
+	s.get("b")
+	s.put("d", 4)
+	if _, ok := s.get("c"); ok {
+		t.Errorf("Expected session c to be dropped")
+	}
+	if _, ok := s.get("b"); !ok {
+		t.Errorf("Expected session b to be kept")
+	}
+	if _, ok := s.get("d"); !ok {
+		t.Errorf("Expected session d to be kept")
+	}
+
+	if n := s.count(func(value int) bool { return value > 2 }); n != 1 {
+		t.Errorf("Expected 1 session above 2, got %d", n)
+	}
+
+	// Step 4: Expired sessions make room before a live one is dropped
+	now = now.Add(2 * time.Hour)
+	s.put("e", 5)
+	if len(s.entries) != 1 {
+		t.Errorf("Expected only session e, got %d sessions", len(s.entries))
+	}
+	s.entries["f"] = &sessionEntry[int]{value: 6, lastUsed: now.Add(-2 * time.Hour)}
+	if n := s.count(func(int) bool { return true }); n != 1 {
+		t.Errorf("Expected expired sessions not to count, got %d", n)
+	}
+}
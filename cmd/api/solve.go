@@ -0,0 +1,205 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/validators"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// solveTimeout bounds how long a single solve may search.
+const solveTimeout = 10 * time.Second
+
+type solveRequest struct {
+	Moves     string `json:"moves"`
+	MaxLength int    `json:"maxLength"`
+}
+
+// SolveHandler finds a shortest solution of the current cube, optionally
+// restricted to a move set such as "<R,U>". The cube itself is not changed.
+func (cm *CubeManager) SolveHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req solveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	var validationErrors []ValidationError
+	if err := validators.ValidateMoveSet(req.Moves); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "moves",
+			Message: err.Error(),
+		})
+	}
+
+	if err := validators.ValidateMaxLength(req.MaxLength); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "maxLength",
+			Message: err.Error(),
+		})
+	}
+
+	if len(validationErrors) > 0 {
+		respondWithValidationError(w, validationErrors)
+		return
+	}
+
+	var faces []string
+	if req.Moves != "" {
+		faces, _ = models.ParseMoveSet(req.Moves)
+	}
+
+	// Search on a copy so that the cube stays available while solving
+	cm.mutex.RLock()
+	cube := *cm.cube
+	cm.mutex.RUnlock()
+
+	ctx, cancel := context.WithTimeout(r.Context(), solveTimeout)
+	defer cancel()
+
+	solution, err := cube.Solve(ctx, models.SolveOptions{Faces: faces, MaxLength: req.MaxLength})
+	if errors.Is(err, context.DeadlineExceeded) {
+		http.Error(w, "solver timed out", http.StatusServiceUnavailable)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	moves := "<U,D,F,B,L,R>"
+	if faces != nil {
+		moves = "<" + strings.Join(faces, ",") + ">"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"moves":    moves,
+		"rotation": solution.Rotation.String(),
+		"solution": solution.Algorithm.String(),
+		"length":   solution.Moves,
+		"optimal":  solution.Optimal,
+		"metrics":  solution.Algorithm.Metrics(),
+	})
+}
+
+// StepSolutionsHandler returns optimal solutions of a step such as the cross
+// for every color orientation of the current cube.
+func (cm *CubeManager) StepSolutionsHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	step := r.URL.Query().Get("step")
+	if err := validators.ValidateStep(step); err != nil {
+		respondWithValidationError(w, []ValidationError{
+			{
+				Field:   "step",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	cm.mutex.RLock()
+	cube := *cm.cube
+	cm.mutex.RUnlock()
+
+	ctx, cancel := context.WithTimeout(r.Context(), solveTimeout)
+	defer cancel()
+
+	solutions, err := cube.SolveStep(ctx, step)
+	if errors.Is(err, context.DeadlineExceeded) {
+		http.Error(w, "solver timed out", http.StatusServiceUnavailable)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":   true,
+		"step":      step,
+		"solutions": solutions,
+	})
+}
+
+// EdgeOrientationHandler reports the misoriented edges of the current cube
+// for an axis, F/B by default, with a shortest sequence that orients them.
+func (cm *CubeManager) EdgeOrientationHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	axis := r.URL.Query().Get("axis")
+	if axis == "" {
+		axis = "fb"
+	}
+	if err := validators.ValidateAxis(axis); err != nil {
+		respondWithValidationError(w, []ValidationError{
+			{
+				Field:   "axis",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	cm.mutex.RLock()
+	cube := *cm.cube
+	cm.mutex.RUnlock()
+
+	ctx, cancel := context.WithTimeout(r.Context(), solveTimeout)
+	defer cancel()
+
+	eo, err := cube.EdgeOrientation(ctx, axis)
+	if errors.Is(err, context.DeadlineExceeded) {
+		http.Error(w, "solver timed out", http.StatusServiceUnavailable)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":         true,
+		"edgeOrientation": eo,
+	})
+}
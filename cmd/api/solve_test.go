@@ -0,0 +1,370 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestSolveHandler tests the SolveHandler function
+func TestSolveHandler(t *testing.T) {
+	testCases := []struct {
+		name             string
+		method           string
+		setup            string
+		requestBody      map[string]interface{}
+		expectedStatus   int
+		expectedSolution string
+		expectedError    string
+		expectedErrors   []ValidationError
+	}{
+		{
+			name:             "2-Gen",
+			method:           "POST",
+			setup:            "R U2 R' U' R U' R'",
+			requestBody:      map[string]interface{}{"moves": "<R,U>"},
+			expectedStatus:   http.StatusOK,
+			expectedSolution: "R U R' U R U2 R'",
+		},
+		{
+			name:             "Unrestricted",
+			method:           "POST",
+			setup:            "F R",
+			requestBody:      map[string]interface{}{},
+			expectedStatus:   http.StatusOK,
+			expectedSolution: "R' F'",
+		},
+		{
+			name:           "Unsolvable In Group",
+			method:         "POST",
+			setup:          "F",
+			requestBody:    map[string]interface{}{"moves": "RU"},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "unsolvable in this group",
+		},
+		{
+			name:           "Invalid Fields",
+			method:         "POST",
+			requestBody:    map[string]interface{}{"moves": "<R,Q>", "maxLength": 40},
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "moves",
+					Message: "invalid move set: unknown move Q",
+				},
+				{
+					Field:   "maxLength",
+					Message: "maxLength must be between 0 and 30, where 0 uses the default",
+				},
+			},
+		},
+		{
+			name:           "Unsupported Move Set",
+			method:         "POST",
+			requestBody:    map[string]interface{}{"moves": "<R,U,L,D>"},
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "moves",
+					Message: "moves must be one of <U,D,F,B,L,R>, <R,U>, <R,U,F>, <R,U,M>, <R,r,U>, <M,U>",
+				},
+			},
+		},
+		{
+			name:           "Method Not Allowed",
+			method:         "GET",
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedError:  "Method not allowed",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cm := NewCubeManager()
+			if tc.setup != "" {
+				cm.cube.ApplyAlgorithm(tc.setup)
+			}
+			before := *cm.cube
+
+			body, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(tc.method, "/api/cube/solve", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(cm.SolveHandler)
+			handler.ServeHTTP(rr, req)
+
+			// Check the status code
+			if status := rr.Code; status != tc.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tc.expectedStatus)
+			}
+
+			if tc.expectedError != "" {
+				if body := strings.TrimSpace(rr.Body.String()); body != tc.expectedError {
+					t.Errorf("Expected error %q, got %q", tc.expectedError, body)
+				}
+				return
+			}
+
+			if tc.expectedErrors != nil {
+				var response ValidationResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to unmarshal error response: %v", err)
+				}
+
+				if !reflect.DeepEqual(response.Errors, tc.expectedErrors) {
+					t.Errorf("Error mismatch:\nExpected: %+v\nActual: %+v", tc.expectedErrors, response.Errors)
+				}
+				return
+			}
+
+			var response struct {
+				Solution string           `json:"solution"`
+				Metrics  models.MoveCount `json:"metrics"`
+			}
+			json.Unmarshal(rr.Body.Bytes(), &response)
+			if response.Solution != tc.expectedSolution {
+				t.Errorf("Expected solution %q, got %v", tc.expectedSolution, response.Solution)
+			}
+
+			solution, _ := models.ParseAlgorithm(tc.expectedSolution)
+			if response.Metrics != solution.Metrics() {
+				t.Errorf("Expected metrics %+v, got %+v", solution.Metrics(), response.Metrics)
+			}
+
+			// Solving must not change the cube
+			if *cm.cube != before {
+				t.Errorf("Expected the cube to be left unchanged")
+			}
+		})
+	}
+}
+
+// TestStepSolutionsHandler tests the StepSolutionsHandler function
+func TestStepSolutionsHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		setup          string
+		expectedStatus int
+		expectedCount  int
+		expectedFirst  int
+		expectedErrors []ValidationError
+	}{
+		{
+			name:           "Cross",
+			method:         "GET",
+			url:            "/api/cube/steps?step=cross",
+			setup:          "R2 D' B' L2 F2 U' R2 B2 U' F2 L2 U' F' R U' B D R' F2 D2",
+			expectedStatus: http.StatusOK,
+			expectedCount:  6,
+			expectedFirst:  6,
+		},
+		{
+			name:           "EOLine",
+			method:         "GET",
+			url:            "/api/cube/steps?step=eoline",
+			setup:          "F",
+			expectedStatus: http.StatusOK,
+			expectedCount:  12,
+			expectedFirst:  0,
+		},
+		{
+			name:           "Missing Step",
+			method:         "GET",
+			url:            "/api/cube/steps",
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "step",
+					Message: "step cannot be empty",
+				},
+			},
+		},
+		{
+			name:           "Invalid Step",
+			method:         "GET",
+			url:            "/api/cube/steps?step=f2l",
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "step",
+					Message: "invalid step: f2l. Valid steps are: cross, xcross, eoline, eocross, fb",
+				},
+			},
+		},
+		{
+			name:           "Method Not Allowed",
+			method:         "POST",
+			url:            "/api/cube/steps?step=cross",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cm := NewCubeManager()
+			if tc.setup != "" {
+				cm.cube.ApplyAlgorithm(tc.setup)
+			}
+
+			req, _ := http.NewRequest(tc.method, tc.url, nil)
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(cm.StepSolutionsHandler)
+			handler.ServeHTTP(rr, req)
+
+			// Check the status code
+			if status := rr.Code; status != tc.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tc.expectedStatus)
+			}
+
+			if tc.expectedErrors != nil {
+				var response ValidationResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to unmarshal error response: %v", err)
+				}
+
+				if !reflect.DeepEqual(response.Errors, tc.expectedErrors) {
+					t.Errorf("Error mismatch:\nExpected: %+v\nActual: %+v", tc.expectedErrors, response.Errors)
+				}
+				return
+			}
+
+			if tc.expectedCount == 0 {
+				return
+			}
+
+			var response struct {
+				Success   bool                  `json:"success"`
+				Solutions []models.StepSolution `json:"solutions"`
+			}
+			if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+
+			if len(response.Solutions) != tc.expectedCount {
+				t.Errorf("Expected %d solutions, got %d", tc.expectedCount, len(response.Solutions))
+			} else if response.Solutions[0].Moves != tc.expectedFirst {
+				t.Errorf("Expected the best solution to take %d moves, got %d", tc.expectedFirst, response.Solutions[0].Moves)
+			}
+		})
+	}
+}
+
+// TestEdgeOrientationHandler tests the EdgeOrientationHandler function
+func TestEdgeOrientationHandler(t *testing.T) {
+	testCases := []struct {
+		name             string
+		method           string
+		url              string
+		setup            string
+		expectedStatus   int
+		expectedAxis     string
+		expectedCount    int
+		expectedSolution string
+		expectedErrors   []ValidationError
+	}{
+		{
+			name:             "Default Axis",
+			method:           "GET",
+			url:              "/api/cube/edge-orientation",
+			setup:            "F",
+			expectedStatus:   http.StatusOK,
+			expectedAxis:     "fb",
+			expectedCount:    4,
+			expectedSolution: "F",
+		},
+		{
+			name:             "RL Axis",
+			method:           "GET",
+			url:              "/api/cube/edge-orientation?axis=rl",
+			setup:            "F",
+			expectedStatus:   http.StatusOK,
+			expectedAxis:     "rl",
+			expectedCount:    0,
+			expectedSolution: "",
+		},
+		{
+			name:           "Invalid Axis",
+			method:         "GET",
+			url:            "/api/cube/edge-orientation?axis=xy",
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "axis",
+					Message: "invalid axis: xy. Valid axes are: fb, rl, ud",
+				},
+			},
+		},
+		{
+			name:           "Method Not Allowed",
+			method:         "POST",
+			url:            "/api/cube/edge-orientation",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cm := NewCubeManager()
+			if tc.setup != "" {
+				cm.cube.ApplyAlgorithm(tc.setup)
+			}
+
+			req, _ := http.NewRequest(tc.method, tc.url, nil)
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(cm.EdgeOrientationHandler)
+
+			handler.ServeHTTP(rr, req)
+
+			// Check the status code
+			if status := rr.Code; status != tc.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tc.expectedStatus)
+			}
+
+			if tc.expectedErrors != nil {
+				var response ValidationResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to unmarshal error response: %v", err)
+				}
+
+				if !reflect.DeepEqual(response.Errors, tc.expectedErrors) {
+					t.Errorf("Error mismatch:\nExpected: %+v\nActual: %+v", tc.expectedErrors, response.Errors)
+				}
+				return
+			}
+
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Success         bool                   `json:"success"`
+				EdgeOrientation models.EdgeOrientation `json:"edgeOrientation"`
+			}
+			if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+
+			eo := response.EdgeOrientation
+			if eo.Axis != tc.expectedAxis {
+				t.Errorf("Expected axis %s, got %s", tc.expectedAxis, eo.Axis)
+			}
+			if eo.Count != tc.expectedCount {
+				t.Errorf("Expected %d bad edges, got %d", tc.expectedCount, eo.Count)
+			}
+			if eo.Solution.String() != tc.expectedSolution {
+				t.Errorf("Expected solution %q, got %q", tc.expectedSolution, eo.Solution.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/validators"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// TrainerManager keeps a recognition trainer for every session in memory,
+// until the session has gone unused for sessionTTL.
+type TrainerManager struct {
+	sessions *sessionStore[*models.Trainer]
+	mutex    sync.Mutex
+}
+
+func NewTrainerManager() *TrainerManager {
+	return &TrainerManager{
+		sessions: newSessionStore[*models.Trainer](sessionTTL, sessionLimit),
+	}
+}
+
+type trainerCaseRequest struct {
+	Session  string   `json:"session"`
+	Category string   `json:"category"`
+	Cases    []string `json:"cases"`
+}
+
+type trainerAnswerRequest struct {
+	Session   string `json:"session"`
+	Guess     string `json:"guess"`
+	Algorithm string `json:"algorithm"`
+}
+
+// TrainerCaseHandler serves the next case of a session as a cube. A new
+// session is started when none is given; a category, optionally narrowed
+// down to some of its cases, selects the subset to train.
+func (tm *TrainerManager) TrainerCaseHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req trainerCaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Session == "" || req.Category != "" {
+		if err := validators.ValidateLibraryCategory(req.Category); err != nil {
+			respondWithValidationError(w, []ValidationError{{
+				Field:   "category",
+				Message: err.Error(),
+			}})
+			return
+		}
+	}
+
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	status := http.StatusOK
+	trainer, ok := tm.sessions.get(req.Session)
+	if req.Session == "" {
+		trainer = models.NewTrainer(rand.New(rand.NewSource(time.Now().UnixNano())))
+		status = http.StatusCreated
+	} else if !ok {
+		http.Error(w, fmt.Sprintf("unknown session: %s", req.Session), http.StatusNotFound)
+		return
+	}
+
+	if req.Category != "" {
+		if err := trainer.SetSubset(req.Category, req.Cases); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if req.Session == "" {
+		req.Session = newSessionID()
+		tm.sessions.put(req.Session, trainer)
+	}
+
+	cube, err := trainer.Next(time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"session":  req.Session,
+		"category": trainer.Category(),
+		"cube":     cube,
+	})
+}
+
+// TrainerAnswerHandler grades the guessed case name and/or the algorithm for
+// the case last served in a session.
+func (tm *TrainerManager) TrainerAnswerHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req trainerAnswerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	var validationErrors []ValidationError
+	if err := validators.ValidateSession(req.Session); err != nil {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   "session",
+			Message: err.Error(),
+		})
+	}
+
+	if req.Algorithm != "" {
+		if err := validators.ValidateAlgorithm(req.Algorithm); err != nil {
+			validationErrors = append(validationErrors, ValidationError{
+				Field:   "algorithm",
+				Message: err.Error(),
+			})
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		respondWithValidationError(w, validationErrors)
+		return
+	}
+
+	alg, _ := models.ParseAlgorithm(req.Algorithm)
+
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	trainer, ok := tm.sessions.get(req.Session)
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown session: %s", req.Session), http.StatusNotFound)
+		return
+	}
+
+	result, err := trainer.Answer(req.Guess, alg, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"result":  result,
+	})
+}
+
+// TrainerStatsHandler returns the accuracy and average time of every case
+// answered in a session.
+func (tm *TrainerManager) TrainerStatsHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(&w)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	session := r.URL.Query().Get("session")
+	if err := validators.ValidateSession(session); err != nil {
+		respondWithValidationError(w, []ValidationError{{
+			Field:   "session",
+			Message: err.Error(),
+		}})
+		return
+	}
+
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	trainer, ok := tm.sessions.get(session)
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown session: %s", session), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"session":  session,
+		"category": trainer.Category(),
+		"stats":    trainer.Stats(),
+	})
+}
@@ -0,0 +1,221 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestTrainerSession trains a single case through the trainer endpoints
+func TestTrainerSession(t *testing.T) {
+	tm := NewTrainerManager()
+
+	send := func(handler http.HandlerFunc, method, url, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, url, bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// Step 1: Start a session on the Ga permutation only
+	rr := send(tm.TrainerCaseHandler, "POST", "/api/trainer/case", `{"category":"pll","cases":["pll-ga"]}`)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+	var served struct {
+		Session  string             `json:"session"`
+		Category string             `json:"category"`
+		Cube     *models.RubiksCube `json:"cube"`
+	}
+	json.Unmarshal(rr.Body.Bytes(), &served)
+	if served.Session == "" || served.Category != "pll" || served.Cube == nil {
+		t.Fatalf("Unexpected response: %s", rr.Body.String())
+	}
+
+	// Step 2: Guess the case by name
+	rr = send(tm.TrainerAnswerHandler, "POST", "/api/trainer/answer", `{"session":"`+served.Session+`","guess":"ga"}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var answered struct {
+		Result models.TrainerResult `json:"result"`
+	}
+	json.Unmarshal(rr.Body.Bytes(), &answered)
+	if !answered.Result.Correct || answered.Result.Case != "pll-ga" {
+		t.Errorf("Expected a correct answer for pll-ga, got %+v", answered.Result)
+	}
+
+	// Step 3: A case has to be served again before the next answer
+	rr = send(tm.TrainerAnswerHandler, "POST", "/api/trainer/answer", `{"session":"`+served.Session+`","guess":"ga"}`)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+
+	// Step 4: Next case in the same session, then a wrong algorithm
+	rr = send(tm.TrainerCaseHandler, "POST", "/api/trainer/case", `{"session":"`+served.Session+`"}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	rr = send(tm.TrainerAnswerHandler, "POST", "/api/trainer/answer", `{"session":"`+served.Session+`","algorithm":"R U R' U'"}`)
+	json.Unmarshal(rr.Body.Bytes(), &answered)
+	if answered.Result.Correct || answered.Result.AlgorithmCorrect == nil || *answered.Result.AlgorithmCorrect {
+		t.Errorf("Expected a wrong algorithm, got %+v", answered.Result)
+	}
+
+	// Step 5: Statistics for the session
+	rr = send(tm.TrainerStatsHandler, "GET", "/api/trainer/stats?session="+served.Session, "")
+	var stats struct {
+		Stats []models.CaseStats `json:"stats"`
+	}
+	json.Unmarshal(rr.Body.Bytes(), &stats)
+	if len(stats.Stats) != 1 || stats.Stats[0].Attempts != 2 || stats.Stats[0].Accuracy != 0.5 {
+		t.Errorf("Unexpected statistics: %s", rr.Body.String())
+	}
+}
+
+// TestTrainerSessionExpires checks that a session unused for a day is gone
+func TestTrainerSessionExpires(t *testing.T) {
+	tm := NewTrainerManager()
+	now := time.Now()
+	tm.sessions.now = func() time.Time { return now }
+
+	// Step 1: Start a session
+	req, _ := http.NewRequest("POST", "/api/trainer/case", bytes.NewBufferString(`{"category":"pll"}`))
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(tm.TrainerCaseHandler).ServeHTTP(rr, req)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+	var served struct {
+		Session string `json:"session"`
+	}
+	json.Unmarshal(rr.Body.Bytes(), &served)
+
+	// Step 2: A day later the session is unknown
+	now = now.Add(sessionTTL + time.Minute)
+	req, _ = http.NewRequest("GET", "/api/trainer/stats?session="+served.Session, nil)
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(tm.TrainerStatsHandler).ServeHTTP(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, rr.Code)
+	}
+}
+
+// TestTrainerErrors tests the validation of the trainer endpoints
+func TestTrainerErrors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		handler        string
+		method         string
+		url            string
+		body           string
+		expectedStatus int
+		expectedErrors []ValidationError
+	}{
+		{
+			name:           "Missing Category",
+			handler:        "case",
+			method:         "POST",
+			url:            "/api/trainer/case",
+			body:           `{}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "category",
+					Message: "category cannot be empty",
+				},
+			},
+		},
+		{
+			name:           "Not A Last-Layer Category",
+			handler:        "case",
+			method:         "POST",
+			url:            "/api/trainer/case",
+			body:           `{"category":"f2l"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Unknown Session",
+			handler:        "case",
+			method:         "POST",
+			url:            "/api/trainer/case",
+			body:           `{"session":"missing"}`,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Invalid Answer",
+			handler:        "answer",
+			method:         "POST",
+			url:            "/api/trainer/answer",
+			body:           `{"algorithm":"Q"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "session",
+					Message: "session cannot be empty",
+				},
+				{
+					Field:   "algorithm",
+					Message: "invalid algorithm: invalid move \"Q\" at position 0",
+				},
+			},
+		},
+		{
+			name:           "Stats Without Session",
+			handler:        "stats",
+			method:         "GET",
+			url:            "/api/trainer/stats",
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []ValidationError{
+				{
+					Field:   "session",
+					Message: "session cannot be empty",
+				},
+			},
+		},
+		{
+			name:           "Method Not Allowed",
+			handler:        "answer",
+			method:         "GET",
+			url:            "/api/trainer/answer",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tm := NewTrainerManager()
+			handlers := map[string]http.HandlerFunc{
+				"case":   tm.TrainerCaseHandler,
+				"answer": tm.TrainerAnswerHandler,
+				"stats":  tm.TrainerStatsHandler,
+			}
+
+			req, _ := http.NewRequest(tc.method, tc.url, bytes.NewBufferString(tc.body))
+			rr := httptest.NewRecorder()
+			handlers[tc.handler].ServeHTTP(rr, req)
+
+			// Check the status code
+			if status := rr.Code; status != tc.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tc.expectedStatus)
+			}
+
+			if tc.expectedErrors != nil {
+				var response ValidationResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to unmarshal error response: %v", err)
+				}
+
+				if !reflect.DeepEqual(response.Errors, tc.expectedErrors) {
+					t.Errorf("Error mismatch:\nExpected: %+v\nActual: %+v", tc.expectedErrors, response.Errors)
+				}
+			}
+		})
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"path/filepath"
 
 	"github.com/DamyanDimitrov101/rubiks-cube-simulator/api"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/store"
 )
 
 func main() {
@@ -15,10 +17,68 @@ func main() {
 
 	cubeManager := api.NewCubeManager()
 
+	// Build the scramble solver tables now rather than on the first request
+	models.PrepareTwoPhase()
+
+	dataDir := filepath.Join("..", "data")
+	algorithmStore, err := store.NewAlgorithmStore(dataDir)
+	if err != nil {
+		log.Fatalf("Failed to open algorithm store in %s: %v", dataDir, err)
+	}
+	collectionManager := api.NewCollectionManager(algorithmStore)
+
+	dailyStore, err := store.NewDailyStore(dataDir)
+	if err != nil {
+		log.Fatalf("Failed to open daily store in %s: %v", dataDir, err)
+	}
+	dailySecret := os.Getenv("DAILY_SECRET")
+	if dailySecret == "" {
+		log.Fatal("DAILY_SECRET is not set. Without it daily scrambles can be predicted.")
+	}
+	dailyManager := api.NewDailyManager(dailySecret, dailyStore)
+	dailyManager.Start()
+
+	fmcStore, err := store.NewFMCStore(dataDir)
+	if err != nil {
+		log.Fatalf("Failed to open FMC store in %s: %v", dataDir, err)
+	}
+	fmcManager := api.NewFMCManager(fmcStore)
+	lessonManager := api.NewLessonManager()
+	trainerManager := api.NewTrainerManager()
+
 	http.HandleFunc("/api/cube", cubeManager.GetCubeHandler)
 	http.HandleFunc("/api/cube/rotate", cubeManager.RotateHandler)
 	http.HandleFunc("/api/cube/move", cubeManager.MoveHandler)
+	http.HandleFunc("/api/cube/algorithm", cubeManager.AlgorithmHandler)
+	http.HandleFunc("/api/cube/memo", cubeManager.MemoHandler)
+	http.HandleFunc("/api/cube/execution", cubeManager.ExecutionHandler)
+	http.HandleFunc("/api/cube/last-layer", cubeManager.LastLayerHandler)
+	http.HandleFunc("/api/cube/matching-algorithms", cubeManager.MatchingAlgorithmsHandler)
+	http.HandleFunc("/api/cube/solve", cubeManager.SolveHandler)
+	http.HandleFunc("/api/cube/steps", cubeManager.StepSolutionsHandler)
+	http.HandleFunc("/api/cube/edge-orientation", cubeManager.EdgeOrientationHandler)
+	http.HandleFunc("/api/cube/hint", cubeManager.HintHandler)
+	http.HandleFunc("/api/cube/inverse", cubeManager.InverseHandler)
 	http.HandleFunc("/api/cube/reset", cubeManager.ResetHandler)
+	http.HandleFunc("/api/algorithms", collectionManager.AlgorithmsHandler)
+	http.HandleFunc("/api/algorithms/transform", api.TransformHandler)
+	http.HandleFunc("/api/algorithms/compare", api.CompareHandler)
+	http.HandleFunc("/api/commutators", api.CommutatorHandler)
+	http.HandleFunc("/api/scramble", api.ScrambleHandler)
+	http.HandleFunc("/api/daily", dailyManager.DailyHandler)
+	http.HandleFunc("/api/daily/solution", dailyManager.DailySolutionHandler)
+	http.HandleFunc("/api/fmc/start", fmcManager.FMCStartHandler)
+	http.HandleFunc("/api/fmc/submit", fmcManager.FMCSubmitHandler)
+	http.HandleFunc("/api/fmc/results", fmcManager.FMCResultsHandler)
+	http.HandleFunc("/api/library", api.LibraryHandler)
+	http.HandleFunc("/api/library/case", api.LibraryCaseHandler)
+	http.HandleFunc("/api/library/apply", cubeManager.LibraryApplyHandler)
+	http.HandleFunc("/api/lessons", api.LessonsHandler)
+	http.HandleFunc("/api/lessons/session", lessonManager.SessionHandler)
+	http.HandleFunc("/api/lessons/move", lessonManager.LessonMoveHandler)
+	http.HandleFunc("/api/trainer/case", trainerManager.TrainerCaseHandler)
+	http.HandleFunc("/api/trainer/answer", trainerManager.TrainerAnswerHandler)
+	http.HandleFunc("/api/trainer/stats", trainerManager.TrainerStatsHandler)
 
 	scriptsBuildDir := filepath.Join("..", "scripts", "build")
 
@@ -0,0 +1,180 @@
+package models
+
+import "strings"
+
+type Turn struct {
+	Face   string `json:"face"`
+	Amount int    `json:"amount"`
+}
+
+type Algorithm []Turn
+
+type layerTurn struct {
+	axis    int
+	layers  []int
+	quarter int
+}
+
+// turnDefinitions describes each move as a rotation of some layers about one
+// axis. quarter is the number of counter-clockwise quarter turns about the
+// positive axis, so a clockwise R (seen from the right) is -1.
+var turnDefinitions = map[string]layerTurn{
+	"U": {1, []int{1}, -1},
+	"D": {1, []int{-1}, 1},
+	"F": {2, []int{1}, -1},
+	"B": {2, []int{-1}, 1},
+	"L": {0, []int{-1}, 1},
+	"R": {0, []int{1}, -1},
+	"M": {0, []int{0}, 1},
+	"E": {1, []int{0}, 1},
+	"S": {2, []int{0}, -1},
+	"u": {1, []int{0, 1}, -1},
+	"d": {1, []int{-1, 0}, 1},
+	"f": {2, []int{0, 1}, -1},
+	"b": {2, []int{-1, 0}, 1},
+	"l": {0, []int{-1, 0}, 1},
+	"r": {0, []int{0, 1}, -1},
+	"x": {0, []int{-1, 0, 1}, -1},
+	"y": {1, []int{-1, 0, 1}, -1},
+	"z": {2, []int{-1, 0, 1}, -1},
+}
+
+var turnPermutations, turnsByEffect = buildTurnPermutations()
+
+func buildTurnPermutations() (map[Turn]permutation, map[permutation]Turn) {
+	permutations := make(map[Turn]permutation)
+	effects := make(map[permutation]Turn)
+	for face, definition := range turnDefinitions {
+		p := identity()
+		quarter := layerPermutation(definition)
+		for amount := 1; amount <= 3; amount++ {
+			p = p.then(quarter)
+			turn := Turn{Face: face, Amount: amount}
+			permutations[turn] = p
+			effects[p] = turn
+		}
+	}
+	return permutations, effects
+}
+
+func layerPermutation(definition layerTurn) permutation {
+	p := identity()
+	for i, f := range facelets {
+		if !containsInt(definition.layers, f.pos[definition.axis]) {
+			continue
+		}
+		moved := facelet{
+			pos:    rotateVec(f.pos, definition.axis, definition.quarter),
+			normal: rotateVec(f.normal, definition.axis, definition.quarter),
+		}
+		p[faceletIndex[moved]] = i
+	}
+	return p
+}
+
+func rotateVec(v vec, axis, quarter int) vec {
+	for q := ((quarter % 4) + 4) % 4; q > 0; q-- {
+		switch axis {
+		case 0:
+			v = vec{v[0], -v[2], v[1]}
+		case 1:
+			v = vec{v[2], v[1], -v[0]}
+		default:
+			v = vec{-v[1], v[0], v[2]}
+		}
+	}
+	return v
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (t Turn) String() string {
+	switch t.Amount {
+	case 2:
+		return t.Face + "2"
+	case 3:
+		return t.Face + "'"
+	default:
+		return t.Face
+	}
+}
+
+func (t Turn) IsRotation() bool {
+	return t.Face == "x" || t.Face == "y" || t.Face == "z"
+}
+
+func (t Turn) IsSlice() bool {
+	return t.Face == "M" || t.Face == "E" || t.Face == "S"
+}
+
+func (t Turn) IsWide() bool {
+	return len(t.Face) == 1 && strings.Contains("udfblr", t.Face)
+}
+
+func (a Algorithm) String() string {
+	parts := make([]string, len(a))
+	for i, turn := range a {
+		parts[i] = turn.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+func (a Algorithm) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+func (a *Algorithm) UnmarshalText(text []byte) error {
+	alg, err := ParseAlgorithm(string(text))
+	if err != nil {
+		return err
+	}
+
+	*a = alg
+	return nil
+}
+
+func (c *RubiksCube) Apply(alg Algorithm) {
+	c.applyPermutation(alg.permutation())
+}
+
+func (c *RubiksCube) ApplyAlgorithm(notation string) error {
+	alg, err := ParseAlgorithm(notation)
+	if err != nil {
+		return err
+	}
+
+	c.Apply(alg)
+	return nil
+}
+
+func (c *RubiksCube) applyPermutation(p permutation) {
+	colors := c.stickers()
+	var moved [faceletCount]Color
+	for i := range p {
+		moved[i] = colors[p[i]]
+	}
+	c.setStickers(moved)
+}
+
+func (a Algorithm) permutation() permutation {
+	p := identity()
+	for _, turn := range a {
+		p = p.then(turn.permutation())
+	}
+	return p
+}
+
+func (t Turn) permutation() permutation {
+	t.Amount = ((t.Amount % 4) + 4) % 4
+	if p, ok := turnPermutations[t]; ok {
+		return p
+	}
+	return identity()
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestApplyMatchesFaceRotations(t *testing.T) {
+	for _, move := range []string{"U", "D", "F", "B", "L", "R", "U'", "D'", "F'", "B'", "L'", "R'", "U2", "R2"} {
+		expected := cubeAfter(t, move)
+
+		cube := New()
+		if err := cube.ApplyAlgorithm(move); err != nil {
+			t.Fatalf("Unexpected error for %s: %v", move, err)
+		}
+
+		if !reflect.DeepEqual(cube, expected) {
+			t.Errorf("ApplyAlgorithm(%s) differs from Move(%s)", move, move)
+		}
+	}
+}
+
+func TestApplySlicesAndRotations(t *testing.T) {
+	testCases := []struct {
+		notation   string
+		equivalent string
+	}{
+		{"M", "R L' x'"},
+		{"E", "U D' y'"},
+		{"S", "F' B z"},
+		{"r", "L x"},
+		{"Uw'", "D' y'"},
+		{"x y x'", "z"},
+	}
+
+	for _, tc := range testCases {
+		cube := New()
+		cube.ApplyAlgorithm(tc.notation)
+
+		expected := New()
+		expected.ApplyAlgorithm(tc.equivalent)
+
+		if !reflect.DeepEqual(cube, expected) {
+			t.Errorf("Expected %s to equal %s", tc.notation, tc.equivalent)
+		}
+	}
+
+	cube := New()
+	cube.ApplyAlgorithm("x")
+	if cube.Up[1][1] != Green || cube.Front[1][1] != Yellow {
+		t.Errorf("After x, Up should be Green and Front Yellow, got %s and %s", cube.Up[1][1], cube.Front[1][1])
+	}
+}
+
+func TestAlgorithmJSON(t *testing.T) {
+	alg := mustParse(t, "R U2 r' M")
+
+	data, err := json.Marshal(map[string]Algorithm{"algorithm": alg})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if string(data) != `{"algorithm":"R U2 r' M"}` {
+		t.Errorf("Expected algorithm to be encoded as notation, got %s", data)
+	}
+
+	var decoded map[string]Algorithm
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(decoded["algorithm"], alg) {
+		t.Errorf("Expected %s after decoding, got %s", alg, decoded["algorithm"])
+	}
+
+	if err := json.Unmarshal([]byte(`"R Q"`), &alg); err == nil {
+		t.Error("Expected error decoding invalid notation, got nil")
+	}
+}
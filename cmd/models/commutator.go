@@ -0,0 +1,219 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+type Commutator struct {
+	Notation   string    `json:"notation"`
+	Algorithm  Algorithm `json:"algorithm"`
+	Moves      int       `json:"moves"`
+	Ergonomics int       `json:"ergonomics"`
+}
+
+type pureCommutator struct {
+	a, b     Algorithm
+	expanded Algorithm
+}
+
+// commutatorsPerCycle caps how many pure commutators are kept for each
+// 3-cycle; only the shortest and most comfortable ones are worth setting up.
+const commutatorsPerCycle = 8
+
+var (
+	commutatorFaces = []string{"U", "D", "F", "B", "L", "R", "M", "E", "S"}
+	commutatorTable map[permutation][]pureCommutator
+	commutatorOnce  sync.Once
+)
+
+// ergonomicCost rates how awkward a move is to execute; R and U turns are
+// the most comfortable. Half turns cost one more than quarter turns.
+var ergonomicCost = map[string]int{
+	"R": 1, "U": 1, "D": 2, "L": 2, "M": 2, "F": 3, "E": 4, "S": 4, "B": 4,
+}
+
+// FindCommutators searches for commutators [A, B] and conjugated commutators
+// [C: [A, B]] that turn the solved cube into target, which must be a 3-cycle
+// of corners or of edges. A has at most three moves, B one move and the
+// setup C at most two. Results are ranked by move count (STM), then
+// ergonomics.
+func FindCommutators(target *RubiksCube, limit int) ([]Commutator, error) {
+	p, err := target.permutation()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.isThreeCycle() {
+		return nil, fmt.Errorf("target must be a 3-cycle of corners or edges")
+	}
+
+	commutatorOnce.Do(buildCommutatorTable)
+
+	seen := make(map[string]bool)
+	var results []Commutator
+	for _, setup := range canonicalSequences(commutatorFaces, 2) {
+		setupPerm := setup.permutation()
+		inner := setupPerm.inverse().then(p).then(setupPerm)
+		if len(setup) > 0 && inner == p {
+			continue
+		}
+
+		for _, pure := range commutatorTable[inner] {
+			if len(setup) > 0 && setup[len(setup)-1].Face == pure.a[0].Face {
+				continue
+			}
+
+			notation := fmt.Sprintf("[%s, %s]", pure.a, pure.b)
+			if len(setup) > 0 {
+				notation = fmt.Sprintf("[%s: %s]", setup, notation)
+			}
+
+			alg := append(append(append(Algorithm{}, setup...), pure.expanded...), setup.Inverse()...)
+			alg = alg.Simplify().Algorithm
+			if seen[alg.String()] {
+				continue
+			}
+			seen[alg.String()] = true
+
+			results = append(results, Commutator{
+				Notation:   notation,
+				Algorithm:  alg,
+				Moves:      alg.Metrics().STM,
+				Ergonomics: ergonomics(alg),
+			})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Moves != results[j].Moves {
+			return results[i].Moves < results[j].Moves
+		}
+		return results[i].Ergonomics < results[j].Ergonomics
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+func buildCommutatorTable() {
+	commutatorTable = make(map[permutation][]pureCommutator)
+	interchanges := canonicalSequences(commutatorFaces, 1)[1:]
+
+	for _, a := range canonicalSequences(commutatorFaces, 3)[1:] {
+		aPerm := a.permutation()
+		for _, b := range interchanges {
+			bPerm := b.permutation()
+			p := aPerm.then(bPerm).then(aPerm.inverse()).then(bPerm.inverse())
+			if !p.isThreeCycle() {
+				continue
+			}
+
+			commutatorTable[p] = append(commutatorTable[p], newPureCommutator(a, b))
+			inverse := p.inverse()
+			commutatorTable[inverse] = append(commutatorTable[inverse], newPureCommutator(b, a))
+		}
+	}
+
+	for p, candidates := range commutatorTable {
+		sort.SliceStable(candidates, func(i, j int) bool {
+			if len(candidates[i].expanded) != len(candidates[j].expanded) {
+				return len(candidates[i].expanded) < len(candidates[j].expanded)
+			}
+			return ergonomics(candidates[i].expanded) < ergonomics(candidates[j].expanded)
+		})
+		if len(candidates) > commutatorsPerCycle {
+			commutatorTable[p] = candidates[:commutatorsPerCycle]
+		}
+	}
+}
+
+func newPureCommutator(a, b Algorithm) pureCommutator {
+	expanded := append(append(Algorithm{}, a...), b...)
+	expanded = append(append(expanded, a.Inverse()...), b.Inverse()...)
+	return pureCommutator{a: a, b: b, expanded: expanded.Simplify().Algorithm}
+}
+
+// canonicalSequences lists every sequence of up to maxLength turns of the
+// given faces, skipping sequences that could be written shorter or that
+// only reorder commuting turns on the same axis. The empty sequence comes
+// first.
+func canonicalSequences(faces []string, maxLength int) []Algorithm {
+	result := []Algorithm{{}}
+	level := []Algorithm{{}}
+	for length := 1; length <= maxLength; length++ {
+		var next []Algorithm
+		for _, prefix := range level {
+			for _, face := range faces {
+				for amount := 1; amount <= 3; amount++ {
+					turn := Turn{Face: face, Amount: amount}
+					if len(prefix) > 0 && !canFollow(prefix[len(prefix)-1], turn) {
+						continue
+					}
+					next = append(next, append(append(Algorithm{}, prefix...), turn))
+				}
+			}
+		}
+		result = append(result, next...)
+		level = next
+	}
+	return result
+}
+
+// canFollow reports whether turn may come right after previous in a
+// canonical sequence: never the same face twice, and turns on one axis only
+// in a fixed order.
+func canFollow(previous, turn Turn) bool {
+	if previous.Face == turn.Face {
+		return false
+	}
+	if sameAxis(previous, turn) {
+		return previous.Face < turn.Face
+	}
+	return true
+}
+
+// isThreeCycle reports whether p moves exactly three corners or three edges
+// around a cycle and returns them to their places when repeated three times.
+func (p permutation) isThreeCycle() bool {
+	corners, edges := 0, 0
+	for i := range p {
+		if p[i] == i {
+			continue
+		}
+		if samePiece(i, p[i]) {
+			return false
+		}
+		switch {
+		case isCorner(i):
+			corners++
+		case isEdge(i):
+			edges++
+		default:
+			return false
+		}
+	}
+
+	if !(corners == 9 && edges == 0) && !(edges == 6 && corners == 0) {
+		return false
+	}
+	return p.then(p).then(p) == identity()
+}
+
+func ergonomics(alg Algorithm) int {
+	score := 0
+	for _, turn := range alg {
+		cost, ok := ergonomicCost[turn.Face]
+		if !ok {
+			cost = 4
+		}
+		if turn.Amount == 2 {
+			cost++
+		}
+		score += cost
+	}
+	return score
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestThreeCycle(t *testing.T) {
+	cube, err := ThreeCycle([3]string{"UFR", "UBL", "RDF"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cube.Up[0][0] != White || cube.Left[0][0] != Red || cube.Back[0][2] != Green {
+		t.Errorf("Expected the UFR corner at UBL, got %s %s %s", cube.Up[0][0], cube.Left[0][0], cube.Back[0][2])
+	}
+
+	if cube.Right[2][0] != White {
+		t.Errorf("Expected the UBL corner at RDF with its U sticker on R, got %s", cube.Right[2][0])
+	}
+
+	order, err := cube.Order()
+	if err != nil || order != 3 {
+		t.Errorf("Expected a 3-cycle to have order 3, got %d (%v)", order, err)
+	}
+
+	invalid := [][3]string{
+		{"UFR", "UB", "DF"},
+		{"UFR", "RUF", "DFL"},
+		{"UF", "UB", "XY"},
+		{"U", "UB", "UF"},
+	}
+	for _, stickers := range invalid {
+		if _, err := ThreeCycle(stickers); err == nil {
+			t.Errorf("Expected error for %v, got nil", stickers)
+		}
+	}
+}
+
+func TestFindCommutators(t *testing.T) {
+	testCases := [][3]string{
+		{"UFR", "RDF", "LUB"},
+		{"UBL", "UFR", "RDF"},
+		{"UF", "UB", "DF"},
+		{"UR", "FL", "DB"},
+		{"UF", "LU", "FR"},
+	}
+
+	for _, stickers := range testCases {
+		target, err := ThreeCycle(stickers)
+		if err != nil {
+			t.Fatalf("Unexpected error building %v: %v", stickers, err)
+		}
+
+		results, err := FindCommutators(target, 5)
+		if err != nil {
+			t.Fatalf("Unexpected error for %v: %v", stickers, err)
+		}
+
+		if len(results) == 0 {
+			t.Errorf("Expected commutators for %v, got none", stickers)
+			continue
+		}
+
+		if len(results) > 5 {
+			t.Errorf("Expected at most 5 results, got %d", len(results))
+		}
+
+		for i, result := range results {
+			cube := New()
+			if err := cube.ApplyAlgorithm(result.Notation); err != nil {
+				t.Fatalf("Unexpected error applying %s: %v", result.Notation, err)
+			}
+
+			if !reflect.DeepEqual(cube, target) {
+				t.Errorf("%s does not perform the cycle %v", result.Notation, stickers)
+			}
+
+			if !strings.HasPrefix(result.Notation, "[") {
+				t.Errorf("Expected bracket notation, got %s", result.Notation)
+			}
+
+			if i > 0 && results[i-1].Moves > result.Moves {
+				t.Errorf("Results are not ranked by move count: %d before %d", results[i-1].Moves, result.Moves)
+			}
+		}
+	}
+}
+
+func TestFindCommutatorsRejectsOtherStates(t *testing.T) {
+	for _, moves := range [][]string{nil, {"R"}, {"R", "U", "R'", "U'"}} {
+		if _, err := FindCommutators(cubeAfter(t, moves...), 5); err == nil {
+			t.Errorf("Expected error for %v, got nil", moves)
+		}
+	}
+}
@@ -53,6 +53,21 @@ func (c *RubiksCube) Reset() {
 	*c = *New()
 }
 
+// IsSolved reports whether every face is a single color, however the cube
+// is held.
+func (c *RubiksCube) IsSolved() bool {
+	for _, face := range c.faces() {
+		for row := 0; row < 3; row++ {
+			for col := 0; col < 3; col++ {
+				if face[row][col] != face[1][1] {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
 func rotateFaceClockwise(face Face) Face {
 	var newFace Face
 	for i := 0; i < 3; i++ {
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/rand"
+	"time"
+)
+
+// DailyDate names the day of t, in UTC, as YYYY-MM-DD.
+func DailyDate(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
+}
+
+// DailyScramble is the scramble of the day for a full cube. The state is
+// drawn with a seed derived from the date and the secret, so every server
+// that shares the secret hands out the same scramble on the same day while
+// nobody without it can work out tomorrow's.
+func DailyScramble(ctx context.Context, secret string, date string) (*Scramble, error) {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(date))
+	seed := int64(binary.BigEndian.Uint64(mac.Sum(nil)))
+
+	return GenerateScramble(ctx, SubsetFull, ScrambleConstraints{}, rand.New(rand.NewSource(seed)))
+}
+
+// VerifySolution applies the scramble and then the solution to a solved
+// cube and reports whether it ends up solved again.
+func VerifySolution(scramble, solution Algorithm) bool {
+	cube := New()
+	cube.Apply(scramble)
+	cube.Apply(solution)
+	return cube.IsSolved()
+}
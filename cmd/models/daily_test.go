@@ -0,0 +1,63 @@
+package models
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDailyDate(t *testing.T) {
+	date := time.Date(2026, 10, 18, 23, 30, 0, 0, time.FixedZone("UTC-2", -2*60*60))
+	if got := DailyDate(date); got != "2026-10-19" {
+		t.Errorf("Expected 2026-10-19, got %s", got)
+	}
+}
+
+func TestDailyScramble(t *testing.T) {
+	ctx := context.Background()
+
+	first, err := DailyScramble(ctx, "secret", "2026-10-18")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	again, err := DailyScramble(ctx, "secret", "2026-10-18")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if first.Scramble.String() != again.Scramble.String() {
+		t.Errorf("Expected the same scramble for the same day, got %s and %s", first.Scramble, again.Scramble)
+	}
+
+	// Another day or another secret gives another scramble
+	for _, tc := range []struct{ secret, date string }{{"secret", "2026-10-19"}, {"other", "2026-10-18"}} {
+		other, err := DailyScramble(ctx, tc.secret, tc.date)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if *other.Cube == *first.Cube {
+			t.Errorf("Expected a different scramble for %s on %s", tc.secret, tc.date)
+		}
+	}
+}
+
+func TestVerifySolution(t *testing.T) {
+	scramble := mustParseAlgorithm("R U R' U'")
+
+	testCases := []struct {
+		solution string
+		solved   bool
+	}{
+		{"U R U' R'", true},
+		{"y U F U' F' y'", true},
+		{"U R U' R' x", true},
+		{"U R U' R", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.solution, func(t *testing.T) {
+			if got := VerifySolution(scramble, mustParseAlgorithm(tc.solution)); got != tc.solved {
+				t.Errorf("Expected %v, got %v", tc.solved, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"context"
+	"fmt"
+)
+
+// EdgeOrientation lists the edges that are misoriented for an axis, named by
+// the place they are in, with an optimal sequence that orients all edges.
+type EdgeOrientation struct {
+	Axis     string    `json:"axis"`
+	BadEdges []string  `json:"badEdges"`
+	Count    int       `json:"count"`
+	Solution Algorithm `json:"solution"`
+	Moves    int       `json:"moves"`
+}
+
+// eoReferenceFaces gives, for each axis, the faces whose stickers decide
+// edge orientation: an edge's reference sticker is the one on the first
+// pair of faces, or on the second pair if it has none there. An edge is
+// oriented when its reference sticker sits on a reference place.
+var eoReferenceFaces = map[string][2]string{
+	"fb": {"UD", "FB"},
+	"rl": {"UD", "RL"},
+	"ud": {"FB", "UD"},
+}
+
+func eoReferences(axis string) [faceletCount]bool {
+	var references [faceletCount]bool
+	faces := eoReferenceFaces[axis]
+	for i := range stickerNames {
+		if !isEdge(i) {
+			continue
+		}
+		for _, group := range faces {
+			found := -1
+			for _, j := range pieceOf[i] {
+				if containsRune(group, stickerNames[j][0]) {
+					found = j
+				}
+			}
+			if found >= 0 {
+				references[i] = found == i
+				break
+			}
+		}
+	}
+	return references
+}
+
+func containsRune(s string, b byte) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] == b {
+			return true
+		}
+	}
+	return false
+}
+
+// edgesOrientedOn reports whether every edge is oriented for the references.
+func edgesOrientedOn(inverse permutation, references [faceletCount]bool) bool {
+	for h, reference := range references {
+		if reference && !references[inverse[h]] {
+			return false
+		}
+	}
+	return true
+}
+
+// edgeOrientationTable tracks which places hold reference stickers, which
+// unlike the orientation of each piece follows from the places alone.
+func edgeOrientationTable(faces []string, axis string) *pruningTable {
+	references := eoReferences(axis)
+	slots := make(map[int]int)
+	for i, f := range edgeFacelets() {
+		slots[f] = i
+	}
+	return buildPruningTable("eo "+axis, faces, func(inverse permutation) patternKey {
+		var k patternKey
+		for s, reference := range references {
+			if reference {
+				k[slots[inverse[s]]] = 1
+			}
+		}
+		return k
+	})
+}
+
+// EdgeOrientation reports the misoriented edges of the cube, held with its
+// centers in the solved orientation, for the axis "fb", "rl" or "ud". Quarter
+// turns of the faces on that axis flip edges; every other turn keeps them.
+func (c *RubiksCube) EdgeOrientation(ctx context.Context, axis string) (*EdgeOrientation, error) {
+	if _, ok := eoReferenceFaces[axis]; !ok {
+		return nil, fmt.Errorf("invalid axis: %s", axis)
+	}
+
+	p, err := c.permutation()
+	if err != nil {
+		return nil, err
+	}
+	p = p.reoriented()
+
+	references := eoReferences(axis)
+	result := &EdgeOrientation{Axis: axis, BadEdges: []string{}}
+	for _, i := range primaryStickers() {
+		if !isEdge(i) {
+			continue
+		}
+		for _, j := range pieceOf[i] {
+			if references[j] && !references[p[j]] {
+				result.BadEdges = append(result.BadEdges, stickerNames[j])
+			}
+		}
+	}
+	result.Count = len(result.BadEdges)
+
+	solved := func(inverse permutation) bool { return edgesOrientedOn(inverse, references) }
+	tables := []*pruningTable{edgeOrientationTable(outerFaces, axis)}
+	solution, err := search(ctx, p, solved, outerFaces, tables, stepMaxLength)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Solution = solution
+	result.Moves = len(solution)
+	return result, nil
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestEdgeOrientationReferences(t *testing.T) {
+	testCases := []struct {
+		setup    string
+		axis     string
+		oriented bool
+	}{
+		{"R U L D", "fb", true},
+		{"F", "fb", false},
+		{"F2 B2", "fb", true},
+		{"F", "rl", true},
+		{"R", "rl", false},
+		{"U", "ud", false},
+		{"R F L B", "ud", true},
+	}
+
+	for _, tc := range testCases {
+		alg, _ := ParseAlgorithm(tc.setup)
+		if oriented := edgesOrientedOn(alg.permutation().inverse(), eoReferences(tc.axis)); oriented != tc.oriented {
+			t.Errorf("Expected edges after %s oriented on %s to be %v", tc.setup, tc.axis, tc.oriented)
+		}
+	}
+}
+
+func TestEdgeOrientation(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setup         string
+		axis          string
+		expectedBad   []string
+		expectedMoves int
+	}{
+		{"F Turn", "F", "fb", []string{"UF", "DF", "FL", "FR"}, 1},
+		{"F Turn On R/L", "F", "rl", []string{}, 0},
+		{"R Turn On R/L", "R", "rl", []string{"UR", "DR", "RF", "RB"}, 1},
+		{"Rotated Cube", "y F y'", "rl", []string{"UR", "DR", "RF", "RB"}, 1},
+		{"Superflip", "U R2 F B R B2 R U2 L B2 R U' D' R2 F R' L B2 U2 F2", "ud", nil, -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cube := New()
+			cube.ApplyAlgorithm(tc.setup)
+
+			result, err := cube.EdgeOrientation(context.Background(), tc.axis)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if tc.expectedBad != nil && !reflect.DeepEqual(result.BadEdges, tc.expectedBad) {
+				t.Errorf("Expected bad edges %v, got %v", tc.expectedBad, result.BadEdges)
+			}
+			if tc.expectedBad == nil && result.Count != 12 {
+				t.Errorf("Expected all 12 edges to be bad, got %v", result.BadEdges)
+			}
+			if result.Count != len(result.BadEdges) {
+				t.Errorf("Count %d does not match %v", result.Count, result.BadEdges)
+			}
+
+			if tc.expectedMoves >= 0 && result.Moves != tc.expectedMoves {
+				t.Errorf("Expected a %d-move solution, got %s", tc.expectedMoves, result.Solution)
+			}
+
+			p, _ := cube.permutation()
+			p = p.reoriented().then(result.Solution.permutation())
+			if !edgesOrientedOn(p.inverse(), eoReferences(tc.axis)) {
+				t.Errorf("Solution %s does not orient the edges", result.Solution)
+			}
+		})
+	}
+}
+
+func TestEdgeOrientationInvalidAxis(t *testing.T) {
+	if _, err := New().EdgeOrientation(context.Background(), "xy"); err == nil {
+		t.Errorf("Expected an error for an unknown axis")
+	}
+}
@@ -0,0 +1,121 @@
+package models
+
+type EquivalenceOptions struct {
+	AUF      bool
+	Rotation bool
+}
+
+// Equivalence describes how the second algorithm has to be adjusted to match
+// the first: PreAUF and PostAUF are U-layer turns done before and after it,
+// Rotation is the whole-cube rotation it is performed from.
+type Equivalence struct {
+	Equivalent bool   `json:"equivalent"`
+	PreAUF     string `json:"preAuf"`
+	PostAUF    string `json:"postAuf"`
+	Rotation   string `json:"rotation"`
+}
+
+type rotation struct {
+	alg  Algorithm
+	perm permutation
+}
+
+var (
+	aufs      = []Algorithm{{}, {{"U", 1}}, {{"U", 2}}, {{"U", 3}}}
+	rotations = buildRotations()
+	centers   = []int{4, 13, 22, 31, 40, 49}
+)
+
+// buildRotations lists the 24 orientations of the cube, each reached by the
+// shortest sequence of x, y and z turns.
+func buildRotations() []rotation {
+	result := []rotation{{Algorithm{}, identity()}}
+	seen := map[permutation]bool{identity(): true}
+
+	for i := 0; i < len(result); i++ {
+		for _, face := range []string{"x", "y", "z"} {
+			for amount := 1; amount <= 3; amount++ {
+				turn := Turn{Face: face, Amount: amount}
+				p := result[i].perm.then(turn.permutation())
+				if seen[p] {
+					continue
+				}
+				seen[p] = true
+
+				alg := append(append(Algorithm{}, result[i].alg...), turn)
+				result = append(result, rotation{alg, p})
+			}
+		}
+	}
+	return result
+}
+
+// reoriented rotates the whole cube so that every center is back in its
+// solved place, making states comparable regardless of how the cube is held.
+func (p permutation) reoriented() permutation {
+	for _, r := range rotations {
+		q := p.then(r.perm)
+		if q.centersSolved() {
+			return q
+		}
+	}
+	return p
+}
+
+func (p permutation) centersSolved() bool {
+	for _, c := range centers {
+		if p[c] != c {
+			return false
+		}
+	}
+	return true
+}
+
+// CompareAlgorithms decides whether two algorithms have the same effect on
+// the cube, optionally allowing U-layer adjustments around the second one
+// and performing it from another orientation. Only with Rotation are the
+// algorithms allowed to leave the cube held differently, so that "r" and
+// "L" match. The cheapest adjustment is reported.
+func CompareAlgorithms(first, second Algorithm, options EquivalenceOptions) Equivalence {
+	normalize := func(p permutation) permutation { return p }
+	candidateRotations := rotations[:1]
+	if options.Rotation {
+		normalize = permutation.reoriented
+		candidateRotations = rotations
+	}
+
+	target := normalize(first.permutation())
+	secondPerm := second.permutation()
+
+	candidateAUFs := aufs[:1]
+	if options.AUF {
+		candidateAUFs = aufs
+	}
+
+	best := Equivalence{}
+	bestCost := -1
+	for _, r := range candidateRotations {
+		conjugated := r.perm.then(secondPerm).then(r.perm.inverse())
+		for _, pre := range candidateAUFs {
+			for _, post := range candidateAUFs {
+				cost := len(r.alg) + len(pre) + len(post)
+				if bestCost >= 0 && cost >= bestCost {
+					continue
+				}
+
+				p := pre.permutation().then(conjugated).then(post.permutation())
+				if normalize(p) == target {
+					best = Equivalence{
+						Equivalent: true,
+						PreAUF:     pre.String(),
+						PostAUF:    post.String(),
+						Rotation:   r.alg.String(),
+					}
+					bestCost = cost
+				}
+			}
+		}
+	}
+
+	return best
+}
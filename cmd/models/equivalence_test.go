@@ -0,0 +1,115 @@
+package models
+
+import "testing"
+
+func TestBuildRotations(t *testing.T) {
+	if len(rotations) != 24 {
+		t.Fatalf("Expected 24 cube orientations, got %d", len(rotations))
+	}
+
+	for _, r := range rotations {
+		if len(r.alg) > 2 {
+			t.Errorf("Expected every orientation to be reachable in two rotations, got %s", r.alg)
+		}
+	}
+}
+
+func TestCompareAlgorithms(t *testing.T) {
+	all := EquivalenceOptions{AUF: true, Rotation: true}
+
+	testCases := []struct {
+		name     string
+		first    string
+		second   string
+		options  EquivalenceOptions
+		expected Equivalence
+	}{
+		{
+			name:     "Identical",
+			first:    "R U R' U'",
+			second:   "R U R' U'",
+			expected: Equivalence{Equivalent: true},
+		},
+		{
+			name:     "Different Moves Same Effect",
+			first:    "R U R' U R U2 R'",
+			second:   "R U R' U R U' U' R' L L'",
+			expected: Equivalence{Equivalent: true},
+		},
+		{
+			name:     "Trailing Rotation Ignored",
+			first:    "R U R' U'",
+			second:   "R U R' U' y",
+			options:  EquivalenceOptions{Rotation: true},
+			expected: Equivalence{Equivalent: true},
+		},
+		{
+			name:     "Trailing Rotation Without Rotation",
+			first:    "R U R' U'",
+			second:   "R U R' U' y",
+			expected: Equivalence{},
+		},
+		{
+			name:     "Different Rotations Without Rotation",
+			first:    "x",
+			second:   "y",
+			expected: Equivalence{},
+		},
+		{
+			name:     "Wide And Outer Turn Without Rotation",
+			first:    "r",
+			second:   "L",
+			expected: Equivalence{},
+		},
+		{
+			name:     "Wide And Outer Turn",
+			first:    "r",
+			second:   "L",
+			options:  EquivalenceOptions{Rotation: true},
+			expected: Equivalence{Equivalent: true},
+		},
+		{
+			name:     "Different Effect",
+			first:    "R U R' U R U2 R'",
+			second:   "R U2 R' U' R U' R'",
+			options:  all,
+			expected: Equivalence{},
+		},
+		{
+			name:     "AUF Required But Not Allowed",
+			first:    "R U R' U R U2 R'",
+			second:   "U' R U R' U R U2 R' U2",
+			expected: Equivalence{},
+		},
+		{
+			name:     "Pre And Post AUF",
+			first:    "R U R' U R U2 R'",
+			second:   "U' R U R' U R U2 R' U2",
+			options:  EquivalenceOptions{AUF: true},
+			expected: Equivalence{Equivalent: true, PreAUF: "U", PostAUF: "U2"},
+		},
+		{
+			name:     "Rotation",
+			first:    "R U R' U'",
+			second:   "F U F' U'",
+			options:  all,
+			expected: Equivalence{Equivalent: true, Rotation: "y"},
+		},
+		{
+			name:     "Rotation Not Allowed",
+			first:    "R U R' U'",
+			second:   "F U F' U'",
+			options:  EquivalenceOptions{AUF: true},
+			expected: Equivalence{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := CompareAlgorithms(mustParse(t, tc.first), mustParse(t, tc.second), tc.options)
+			if result != tc.expected {
+				t.Errorf("Expected %+v, got %+v", tc.expected, result)
+			}
+		})
+	}
+}
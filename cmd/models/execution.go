@@ -0,0 +1,252 @@
+package models
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ExecutionStep is one target of a blindfolded solve: the setup moves, the
+// swap algorithm and the undo of the setup. Parity steps have Kind "parity"
+// and no target.
+type ExecutionStep struct {
+	Kind    string    `json:"kind"`
+	Target  string    `json:"target"`
+	Sticker string    `json:"sticker"`
+	Setup   Algorithm `json:"setup"`
+	Swap    Algorithm `json:"swap"`
+	Undo    Algorithm `json:"undo"`
+}
+
+func (s ExecutionStep) Algorithm() Algorithm {
+	alg := append(append(Algorithm{}, s.Setup...), s.Swap...)
+	return append(alg, s.Undo...)
+}
+
+// Execution is the complete blindfolded solution. Rotation brings the
+// centers to their solved places before the first step.
+type Execution struct {
+	Method    string          `json:"method"`
+	Rotation  Algorithm       `json:"rotation"`
+	Steps     []ExecutionStep `json:"steps"`
+	Algorithm Algorithm       `json:"algorithm"`
+	Moves     int             `json:"moves"`
+}
+
+// swapMethod swaps the buffer with the sticker at spot. Targets are brought
+// to spot with at most depth setup moves made of the given faces that leave
+// every other piece touched by the swap alone. Exceptions replace setup and swap for
+// targets that cannot be set up that way.
+type swapMethod struct {
+	buffer     string
+	spot       string
+	swap       string
+	faces      []string
+	depth      int
+	exceptions map[string]string
+	once       sync.Once
+	setups     map[int]Algorithm
+}
+
+const (
+	MethodOldPochmann = "op"
+	MethodM2          = "m2"
+)
+
+var (
+	oldPochmannCorners = &swapMethod{
+		buffer: "UBL",
+		spot:   "RDF",
+		swap:   "R U' R' U' R U R' F' R U R' U' R' F R",
+		faces:  []string{"U", "D", "F", "B", "L", "R"},
+		depth:  2,
+	}
+	oldPochmannEdges = &swapMethod{
+		buffer: "UR",
+		spot:   "UL",
+		swap:   "R U R' U' R' F R2 U' R' U' R U R' F'",
+		faces:  []string{"U", "D", "F", "B", "L", "R", "M", "E", "S"},
+		depth:  4,
+	}
+	m2Edges = &swapMethod{
+		buffer: "DF",
+		spot:   "UB",
+		swap:   "M2",
+		faces:  []string{"U", "D", "F", "B", "L", "R"},
+		depth:  3,
+		exceptions: map[string]string{
+			"UF": "U2 M' U2 M'",
+			"DB": "M U2 M U2",
+			"FU": "D M' U R2 U' M U R2 U' D' M2",
+			"BD": "M2 D U R2 U' M' U R2 U' M D'",
+			"BU": "U B' R U' B M2 B' U R' B U'",
+		},
+	}
+)
+
+// Edges are solved first. After an odd number of edge targets the parity
+// algorithm swaps UB and UL, which the odd number of corner swaps returns.
+// For Old Pochmann it also swaps back UFR and UBR, left swapped by the
+// T-perms; for M2 it undoes the half-turned M slice.
+var parityAlgorithms = map[string]string{
+	MethodOldPochmann: "R U R' F' R U2 R' U2 R' F R U R U2 R' U'",
+	MethodM2:          "D' L2 D M2 D' L2 D",
+}
+
+func (m *swapMethod) setup(target int) (Algorithm, bool) {
+	m.once.Do(m.buildSetups)
+	alg, ok := m.setups[target]
+	return alg, ok
+}
+
+func (m *swapMethod) buildSetups() {
+	m.setups = make(map[int]Algorithm)
+	spot := mustParseSticker(m.spot)
+	swap := mustParseAlgorithm(m.swap).permutation()
+
+	var fixed []int
+	for i := range swap {
+		if swap[i] != i && !samePiece(i, spot) {
+			fixed = append(fixed, i)
+		}
+	}
+
+	for _, setup := range canonicalSequences(m.faces, m.depth) {
+		p := setup.permutation()
+		if !p.fixes(fixed) {
+			continue
+		}
+
+		target := p[spot]
+		if best, ok := m.setups[target]; ok {
+			if len(best) < len(setup) || len(best) == len(setup) && ergonomics(best) <= ergonomics(setup) {
+				continue
+			}
+		}
+		m.setups[target] = setup
+	}
+}
+
+func (p permutation) fixes(positions []int) bool {
+	for _, i := range positions {
+		if p[i] != i {
+			return false
+		}
+	}
+	return true
+}
+
+func mustParseSticker(name string) int {
+	i, err := ParseSticker(name)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+func mustParseAlgorithm(notation string) Algorithm {
+	alg, err := ParseAlgorithm(notation)
+	if err != nil {
+		panic(err)
+	}
+	return alg
+}
+
+// step returns the algorithm that shoots the buffer to target, which is
+// currently found at slot.
+func (m *swapMethod) step(kind string, target, slot int) (ExecutionStep, error) {
+	step := ExecutionStep{
+		Kind:    kind,
+		Target:  speffzLetters[target],
+		Sticker: stickerNames[target],
+	}
+
+	if exception, ok := m.exceptions[stickerNames[slot]]; ok {
+		step.Swap = mustParseAlgorithm(exception)
+		return step, nil
+	}
+
+	setup, ok := m.setup(slot)
+	if !ok {
+		return step, fmt.Errorf("no setup found for target %s", stickerNames[slot])
+	}
+
+	step.Setup = setup
+	step.Swap = mustParseAlgorithm(m.swap)
+	step.Undo = setup.Inverse()
+	return step, nil
+}
+
+// Execution generates a blindfolded solution: Old Pochmann for corners and
+// either Old Pochmann or M2 for edges. Targets follow the memo, shooting to
+// twisted and flipped pieces as well. The result is checked to solve the
+// cube before it is returned.
+func (c *RubiksCube) Execution(edgeMethod string) (*Execution, error) {
+	var edges *swapMethod
+	switch edgeMethod {
+	case MethodOldPochmann:
+		edges = oldPochmannEdges
+	case MethodM2:
+		edges = m2Edges
+	default:
+		return nil, fmt.Errorf("invalid method: %s", edgeMethod)
+	}
+
+	scrambled, err := c.permutation()
+	if err != nil {
+		return nil, err
+	}
+
+	held := scrambled.heldRotation()
+	p := scrambled.then(held.perm)
+	execution := &Execution{Method: edgeMethod, Rotation: append(Algorithm{}, held.alg...)}
+
+	cornerTargets, _ := p.trace(mustParseSticker(oldPochmannCorners.buffer), speffzLetters, true)
+	edgeTargets, _ := p.trace(mustParseSticker(edges.buffer), speffzLetters, true)
+
+	m2 := mustParseAlgorithm("M2").permutation()
+	for k, target := range edgeTargets {
+		// Every M2 step leaves the M slice turned by a half turn, so after an
+		// odd number of them UF and DB have traded places.
+		slot := target
+		if edgeMethod == MethodM2 && k%2 == 1 && (samePiece(target, mustParseSticker("UF")) || samePiece(target, mustParseSticker("DB"))) {
+			slot = m2[target]
+		}
+
+		step, err := edges.step("edge", target, slot)
+		if err != nil {
+			return nil, err
+		}
+		execution.Steps = append(execution.Steps, step)
+	}
+
+	if len(edgeTargets)%2 == 1 {
+		execution.Steps = append(execution.Steps, parityStep(edgeMethod))
+	}
+
+	for _, target := range cornerTargets {
+		step, err := oldPochmannCorners.step("corner", target, target)
+		if err != nil {
+			return nil, err
+		}
+		execution.Steps = append(execution.Steps, step)
+	}
+
+	execution.Algorithm = append(Algorithm{}, execution.Rotation...)
+	for _, step := range execution.Steps {
+		execution.Algorithm = append(execution.Algorithm, step.Algorithm()...)
+	}
+	execution.Moves = execution.Algorithm.Metrics().STM
+
+	if scrambled.then(execution.Algorithm.permutation()) != identity() {
+		return nil, fmt.Errorf("execution does not solve the cube")
+	}
+
+	return execution, nil
+}
+
+func parityStep(method string) ExecutionStep {
+	return ExecutionStep{
+		Kind: "parity",
+		Swap: mustParseAlgorithm(parityAlgorithms[method]),
+	}
+}
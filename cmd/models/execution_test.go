@@ -0,0 +1,119 @@
+package models
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSwapAlgorithms(t *testing.T) {
+	for _, m := range []*swapMethod{oldPochmannCorners, oldPochmannEdges, m2Edges} {
+		m.once.Do(m.buildSetups)
+
+		buffer := mustParseSticker(m.buffer)
+		for i := range stickerNames {
+			if len(pieceOf[i]) != len(pieceOf[buffer]) || samePiece(i, buffer) {
+				continue
+			}
+			if _, ok := m.exceptions[stickerNames[i]]; ok {
+				continue
+			}
+			if _, ok := m.setup(i); !ok {
+				t.Errorf("Expected a setup for %s from buffer %s", stickerNames[i], m.buffer)
+			}
+		}
+	}
+}
+
+func TestExecutionSolves(t *testing.T) {
+	faces := []string{"U", "D", "F", "B", "L", "R"}
+	r := rand.New(rand.NewSource(1))
+
+	for n := 0; n < 50; n++ {
+		var scramble Algorithm
+		for k := 0; k < 25; k++ {
+			scramble = append(scramble, Turn{Face: faces[r.Intn(len(faces))], Amount: 1 + r.Intn(3)})
+		}
+
+		for _, method := range []string{MethodOldPochmann, MethodM2} {
+			cube := New()
+			cube.Apply(scramble)
+
+			execution, err := cube.Execution(method)
+			if err != nil {
+				t.Fatalf("Unexpected error for %s with %s: %v", scramble, method, err)
+			}
+
+			cube.Apply(execution.Algorithm)
+			if !reflect.DeepEqual(*cube, *New()) {
+				t.Errorf("Expected %s execution to solve %s", method, scramble)
+			}
+		}
+	}
+}
+
+func TestExecutionSteps(t *testing.T) {
+	cube, _ := ThreeCycle([3]string{"UBL", "UFR", "RDF"})
+
+	execution, err := cube.Execution(MethodOldPochmann)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(execution.Steps) != 2 {
+		t.Fatalf("Expected 2 steps, got %+v", execution.Steps)
+	}
+
+	first, second := execution.Steps[0], execution.Steps[1]
+	if first.Target != "P" || len(first.Setup) != 0 || first.Swap.String() != oldPochmannCorners.swap {
+		t.Errorf("Expected P to be swapped without setup, got %+v", first)
+	}
+
+	if second.Target != "C" || second.Kind != "corner" || second.Undo.String() != second.Setup.Inverse().String() {
+		t.Errorf("Expected C to be set up and undone, got %+v", second)
+	}
+}
+
+func TestExecutionParity(t *testing.T) {
+	for _, method := range []string{MethodOldPochmann, MethodM2} {
+		cube := New()
+		cube.ApplyAlgorithm("R U R' U' R' F R2 U' R' U' R U R' F'")
+
+		execution, err := cube.Execution(method)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var kinds []string
+		for _, step := range execution.Steps {
+			kinds = append(kinds, step.Kind)
+		}
+		if !strings.Contains(strings.Join(kinds, " "), "edge parity corner") {
+			t.Errorf("Expected a parity step between edges and corners for %s, got %v", method, kinds)
+		}
+	}
+}
+
+func TestExecutionRotatedAndInvalid(t *testing.T) {
+	cube := New()
+	cube.ApplyAlgorithm("R U2 F' x y")
+
+	execution, err := cube.Execution(MethodM2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(execution.Rotation) == 0 {
+		t.Error("Expected a rotation to bring the centers back")
+	}
+
+	cube.Apply(execution.Algorithm)
+	if !reflect.DeepEqual(*cube, *New()) {
+		t.Error("Expected the execution to solve a rotated cube")
+	}
+
+	if _, err := New().Execution("3style"); err == nil {
+		t.Error("Expected error for an unknown method")
+	}
+}
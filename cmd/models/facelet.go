@@ -0,0 +1,173 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Facelets are indexed face by face in struct order (up, down, front, back,
+// left, right), row-major within each face. Every facelet also has a position
+// and an outward normal in a coordinate system with x pointing right, y up and
+// z towards the viewer, which is what ties the flat arrays to the 3D puzzle.
+
+const faceletCount = 54
+
+type vec [3]int
+
+type facelet struct {
+	pos    vec
+	normal vec
+}
+
+type permutation [faceletCount]int
+
+var (
+	facelets, faceletIndex = buildFacelets()
+	pieceOf                = buildPieces()
+	solvedColors           = New().stickers()
+	homeLookup             = buildHomeLookup()
+)
+
+func buildFacelets() ([faceletCount]facelet, map[facelet]int) {
+	var all [faceletCount]facelet
+	index := make(map[facelet]int)
+	for f := 0; f < 6; f++ {
+		for row := 0; row < 3; row++ {
+			for col := 0; col < 3; col++ {
+				i := f*9 + row*3 + col
+				all[i] = faceletGeometry(f, row, col)
+				index[all[i]] = i
+			}
+		}
+	}
+	return all, index
+}
+
+func buildPieces() [faceletCount][]int {
+	var pieces [faceletCount][]int
+	for i := range facelets {
+		for j := range facelets {
+			if facelets[i].pos == facelets[j].pos {
+				pieces[i] = append(pieces[i], j)
+			}
+		}
+	}
+	return pieces
+}
+
+func buildHomeLookup() map[string]int {
+	lookup := make(map[string]int)
+	for i := range facelets {
+		lookup[pieceKey(solvedColors, i)] = i
+	}
+	return lookup
+}
+
+func faceletGeometry(face, row, col int) facelet {
+	switch face {
+	case 0:
+		return facelet{vec{col - 1, 1, row - 1}, vec{0, 1, 0}}
+	case 1:
+		return facelet{vec{col - 1, -1, 1 - row}, vec{0, -1, 0}}
+	case 2:
+		return facelet{vec{col - 1, 1 - row, 1}, vec{0, 0, 1}}
+	case 3:
+		return facelet{vec{1 - col, 1 - row, -1}, vec{0, 0, -1}}
+	case 4:
+		return facelet{vec{-1, 1 - row, col - 1}, vec{-1, 0, 0}}
+	default:
+		return facelet{vec{1, 1 - row, 1 - col}, vec{1, 0, 0}}
+	}
+}
+
+// pieceKey identifies the sticker at index i by the colors of the piece it
+// belongs to together with its own color.
+func pieceKey(colors [faceletCount]Color, i int) string {
+	var pieceColors []string
+	for _, j := range pieceOf[i] {
+		pieceColors = append(pieceColors, string(colors[j]))
+	}
+	sort.Strings(pieceColors)
+	return strings.Join(pieceColors, ",") + "/" + string(colors[i])
+}
+
+func (c *RubiksCube) faces() [6]*Face {
+	return [6]*Face{&c.Up, &c.Down, &c.Front, &c.Back, &c.Left, &c.Right}
+}
+
+func (c *RubiksCube) stickers() [faceletCount]Color {
+	var colors [faceletCount]Color
+	for f, face := range c.faces() {
+		for row := 0; row < 3; row++ {
+			for col := 0; col < 3; col++ {
+				colors[f*9+row*3+col] = face[row][col]
+			}
+		}
+	}
+	return colors
+}
+
+func (c *RubiksCube) setStickers(colors [faceletCount]Color) {
+	for f, face := range c.faces() {
+		for row := 0; row < 3; row++ {
+			for col := 0; col < 3; col++ {
+				face[row][col] = colors[f*9+row*3+col]
+			}
+		}
+	}
+}
+
+// permutation recovers, for every facelet, the solved position of the sticker
+// currently found there. It fails when the colors do not describe a cube that
+// can be assembled from the pieces of a solved one.
+func (c *RubiksCube) permutation() (permutation, error) {
+	colors := c.stickers()
+
+	var p permutation
+	var used [faceletCount]bool
+	for i := range colors {
+		home, ok := homeLookup[pieceKey(colors, i)]
+		if !ok || used[home] {
+			return p, fmt.Errorf("invalid cube state: unexpected sticker %s at facelet %d", colors[i], i)
+		}
+		used[home] = true
+		p[i] = home
+	}
+	return p, nil
+}
+
+func fromPermutation(p permutation) *RubiksCube {
+	var colors [faceletCount]Color
+	for i := range p {
+		colors[i] = solvedColors[p[i]]
+	}
+	cube := &RubiksCube{}
+	cube.setStickers(colors)
+	return cube
+}
+
+func identity() permutation {
+	var p permutation
+	for i := range p {
+		p[i] = i
+	}
+	return p
+}
+
+// then returns the permutation obtained by applying q after p.
+func (p permutation) then(q permutation) permutation {
+	var r permutation
+	for i := range r {
+		r[i] = p[q[i]]
+	}
+	return r
+}
+
+func (p permutation) inverse() permutation {
+	var r permutation
+	for i := range p {
+		r[p[i]] = i
+	}
+	return r
+}
@@ -0,0 +1,114 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// FMCRules are the conditions of a fewest-moves attempt. TimeLimit is in
+// seconds. The WCA rules allow an hour, outer and wide turns and at most 80
+// moves, and forbid slice turns.
+type FMCRules struct {
+	TimeLimit   int  `json:"timeLimit"`
+	AllowSlices bool `json:"allowSlices"`
+	AllowWide   bool `json:"allowWide"`
+	MaxMoves    int  `json:"maxMoves"`
+}
+
+func DefaultFMCRules() FMCRules {
+	return FMCRules{
+		TimeLimit: 3600,
+		AllowWide: true,
+		MaxMoves:  80,
+	}
+}
+
+// fmcPadding starts and ends every fewest-moves scramble, like the WCA
+// scrambles do, so that the scramble does not give away the start or the
+// end of a short solution.
+var fmcPadding = Algorithm{{Face: "R", Amount: 3}, {Face: "U", Amount: 3}, {Face: "F", Amount: 1}}
+
+// GenerateFMCScramble returns a WCA-style fewest-moves scramble: R' U' F, a
+// near-optimal scramble of a random state and R' U' F again. The middle part
+// neither starts with a turn about the F axis nor ends with one about the R
+// axis, so nothing cancels with the padding.
+func GenerateFMCScramble(ctx context.Context, rng *rand.Rand) (*Scramble, error) {
+	for {
+		solution, err := solveTwoPhase(ctx, scrambleSubsets[SubsetFull].random(rng))
+		if err != nil {
+			return nil, err
+		}
+
+		middle := solution.Inverse()
+		if len(middle) == 0 || sameAxis(middle[0], fmcPadding[2]) || sameAxis(middle[len(middle)-1], fmcPadding[0]) {
+			continue
+		}
+
+		scramble := append(append(append(Algorithm{}, fmcPadding...), middle...), fmcPadding...)
+		cube := New()
+		cube.Apply(scramble)
+		return &Scramble{
+			Subset:   SubsetFull,
+			Scramble: scramble,
+			Moves:    len(scramble),
+			Cube:     cube,
+		}, nil
+	}
+}
+
+// FMCScore is the outcome of a fewest-moves attempt. Moves are counted like
+// the WCA does: every outer or wide turn is one move, a slice turn, when it
+// is allowed, is two and rotations are free. A solution that breaks a rule
+// is a DNF, with the rule it breaks as the reason. Time is in seconds.
+type FMCScore struct {
+	Solution string  `json:"solution"`
+	Moves    int     `json:"moves"`
+	DNF      bool    `json:"dnf"`
+	Reason   string  `json:"reason,omitempty"`
+	Time     float64 `json:"time"`
+}
+
+// Score checks a solution submitted elapsed after the scramble was handed
+// out. The solution may use NISS: moves in parentheses count as done on the
+// inverse scramble.
+func (r FMCRules) Score(scramble Algorithm, solution string, elapsed time.Duration) FMCScore {
+	score := FMCScore{Solution: solution, Time: elapsed.Seconds()}
+	dnf := func(format string, args ...interface{}) FMCScore {
+		score.DNF = true
+		score.Moves = 0
+		score.Reason = fmt.Sprintf(format, args...)
+		return score
+	}
+
+	if elapsed > time.Duration(r.TimeLimit)*time.Second {
+		return dnf("submitted after the time limit of %d seconds", r.TimeLimit)
+	}
+
+	alg, err := ParseNISS(solution)
+	if err != nil {
+		return dnf("invalid notation: %v", err)
+	}
+
+	for i, turn := range alg {
+		if turn.IsSlice() && !r.AllowSlices {
+			return dnf("slice turn %s at move %d is not allowed", turn, i+1)
+		}
+		if turn.IsWide() && !r.AllowWide {
+			return dnf("wide turn %s at move %d is not allowed", turn, i+1)
+		}
+	}
+
+	score.Moves = alg.Metrics().HTM
+	if score.Moves > r.MaxMoves {
+		return dnf("solution has %d moves, more than the limit of %d", score.Moves, r.MaxMoves)
+	}
+	if !VerifySolution(scramble, alg) {
+		return dnf("solution does not solve the cube")
+	}
+	if IsInverse(scramble, alg) {
+		return dnf("solution is the inverse of the scramble")
+	}
+	return score
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestFMCScore(t *testing.T) {
+	scramble := mustParseAlgorithm("R U R' U' F2")
+	slices := DefaultFMCRules()
+	slices.AllowSlices = true
+	noWide := DefaultFMCRules()
+	noWide.AllowWide = false
+	short := DefaultFMCRules()
+	short.MaxMoves = 4
+
+	testCases := []struct {
+		name     string
+		rules    FMCRules
+		solution string
+		elapsed  time.Duration
+		moves    int
+		reason   string
+	}{
+		{"Solved", DefaultFMCRules(), "F2 U R U' R D2 U2 L2 R2 D2 U2 L2", time.Minute, 12, ""},
+		{"Rotations Are Free", DefaultFMCRules(), "y2 B2 U L U' L D2 U2 R2 L2 D2 U2 R2 x2", time.Minute, 12, ""},
+		{"NISS", DefaultFMCRules(), "F2 U R U' R D2 U2 L2 (L2 U2 D2 R2)", time.Minute, 12, ""},
+		{"Wide Turn", DefaultFMCRules(), "F2 U Lw x U' x' Lw' R2 D2 U2 L2 R2 D2 U2 L2", time.Minute, 13, ""},
+		{"Slice Counts Two", slices, "F2 U R U' R D2 U2 L2 R2 D2 U2 L2 M M'", time.Minute, 16, ""},
+		{"Slice Not Allowed", DefaultFMCRules(), "F2 U R U' R' M M'", time.Minute, 0, "slice turn M at move 6 is not allowed"},
+		{"Wide Not Allowed", noWide, "F2 U r U' r' x'", time.Minute, 0, "wide turn r at move 3 is not allowed"},
+		{"Too Many Moves", short, "F2 U R U' R'", time.Minute, 0, "solution has 5 moves, more than the limit of 4"},
+		{"Not Solved", DefaultFMCRules(), "F2 U R U' R", time.Minute, 0, "solution does not solve the cube"},
+		{"Invalid Notation", DefaultFMCRules(), "F2 Q", time.Minute, 0, "invalid notation: invalid move \"Q\" at position 3"},
+		{"Inverse Of Scramble", DefaultFMCRules(), "F2 U R U' R'", time.Minute, 0, "solution is the inverse of the scramble"},
+		{"Inverse With Rotation", DefaultFMCRules(), "F2 U R2 R' U' R' y", time.Minute, 0, "solution is the inverse of the scramble"},
+		{"Rotated Inverse", DefaultFMCRules(), "y L2 U F U' F'", time.Minute, 0, "solution is the inverse of the scramble"},
+		{"Inverse With Wide Turns", DefaultFMCRules(), "F2 U Lw x U' x' Lw'", time.Minute, 0, "solution is the inverse of the scramble"},
+		{"Too Late", DefaultFMCRules(), "F2 U R U' R'", 61 * time.Minute, 0, "submitted after the time limit of 3600 seconds"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			score := tc.rules.Score(scramble, tc.solution, tc.elapsed)
+			if score.Moves != tc.moves || score.Reason != tc.reason || score.DNF != (tc.reason != "") {
+				t.Errorf("Expected %d moves and reason %q, got %+v", tc.moves, tc.reason, score)
+			}
+		})
+	}
+}
+
+func TestGenerateFMCScramble(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		scramble, err := GenerateFMCScramble(context.Background(), rng)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		moves := scramble.Scramble
+		if moves.String() != moves.Simplify().Algorithm.String() {
+			t.Errorf("Expected no cancellations in %s", moves)
+		}
+		if len(moves) > twoPhaseMaxLength+2*len(fmcPadding) {
+			t.Errorf("Expected at most %d moves, got %d", twoPhaseMaxLength+2*len(fmcPadding), len(moves))
+		}
+		if moves[:3].String() != "R' U' F" || moves[len(moves)-3:].String() != "R' U' F" {
+			t.Errorf("Expected R' U' F padding, got %s", moves)
+		}
+
+		cube := New()
+		cube.Apply(moves)
+		if *cube != *scramble.Cube {
+			t.Errorf("Scramble %s does not reach the state", moves)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package models
+
+// Cube states form a group: every state is the permutation of stickers that
+// takes the solved cube to it. Multiplication follows the cubing convention
+// of applying the left operand first, so the state of "R U" is R.Multiply(U).
+
+func (c *RubiksCube) Multiply(other *RubiksCube) (*RubiksCube, error) {
+	p, err := c.permutation()
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := other.permutation()
+	if err != nil {
+		return nil, err
+	}
+
+	return fromPermutation(p.then(q)), nil
+}
+
+func (c *RubiksCube) Inverse() (*RubiksCube, error) {
+	p, err := c.permutation()
+	if err != nil {
+		return nil, err
+	}
+
+	return fromPermutation(p.inverse()), nil
+}
+
+func (c *RubiksCube) Power(n int) (*RubiksCube, error) {
+	p, err := c.permutation()
+	if err != nil {
+		return nil, err
+	}
+
+	if n < 0 {
+		p = p.inverse()
+		n = -n
+	}
+
+	result := identity()
+	for n > 0 {
+		if n%2 == 1 {
+			result = result.then(p)
+		}
+		p = p.then(p)
+		n /= 2
+	}
+
+	return fromPermutation(result), nil
+}
+
+// Order returns how many times the state has to be repeated to get back to
+// the solved cube.
+func (c *RubiksCube) Order() (int, error) {
+	p, err := c.permutation()
+	if err != nil {
+		return 0, err
+	}
+
+	return p.order(), nil
+}
+
+func (p permutation) order() int {
+	order := 1
+	var visited [faceletCount]bool
+	for i := range p {
+		length := 0
+		for j := i; !visited[j]; j = p[j] {
+			visited[j] = true
+			length++
+		}
+		if length > 0 {
+			order = lcm(order, length)
+		}
+	}
+	return order
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func lcm(a, b int) int {
+	return a / gcd(a, b) * b
+}
@@ -0,0 +1,133 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func cubeAfter(t *testing.T, moves ...string) *RubiksCube {
+	t.Helper()
+
+	cube := New()
+	for _, move := range moves {
+		if err := cube.Move(move); err != nil {
+			t.Fatalf("Error executing move %s: %v", move, err)
+		}
+	}
+	return cube
+}
+
+func TestMultiply(t *testing.T) {
+	r := cubeAfter(t, "R")
+	u := cubeAfter(t, "U")
+
+	product, err := r.Multiply(u)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := cubeAfter(t, "R", "U")
+	if !reflect.DeepEqual(product, expected) {
+		t.Errorf("Expected R * U to equal R U, got %v", product)
+	}
+
+	product, err = u.Multiply(r)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if reflect.DeepEqual(product, expected) {
+		t.Error("Expected U * R to differ from R U")
+	}
+}
+
+func TestInverse(t *testing.T) {
+	cube := cubeAfter(t, "R", "U", "F'", "D2")
+
+	inverse, err := cube.Inverse()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := cubeAfter(t, "D2", "F", "U'", "R'")
+	if !reflect.DeepEqual(inverse, expected) {
+		t.Errorf("Expected inverse to equal D2 F U' R', got %v", inverse)
+	}
+
+	product, err := cube.Multiply(inverse)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(product, New()) {
+		t.Errorf("Expected a state times its inverse to be solved, got %v", product)
+	}
+}
+
+func TestPower(t *testing.T) {
+	cube := cubeAfter(t, "R", "U")
+
+	testCases := []struct {
+		n        int
+		expected *RubiksCube
+	}{
+		{0, New()},
+		{1, cubeAfter(t, "R", "U")},
+		{3, cubeAfter(t, "R", "U", "R", "U", "R", "U")},
+		{-1, cubeAfter(t, "U'", "R'")},
+		{105, New()},
+	}
+
+	for _, tc := range testCases {
+		power, err := cube.Power(tc.n)
+		if err != nil {
+			t.Fatalf("Unexpected error for n=%d: %v", tc.n, err)
+		}
+
+		if !reflect.DeepEqual(power, tc.expected) {
+			t.Errorf("Unexpected result for power %d: %v", tc.n, power)
+		}
+	}
+}
+
+func TestOrder(t *testing.T) {
+	testCases := []struct {
+		moves    []string
+		expected int
+	}{
+		{nil, 1},
+		{[]string{"R"}, 4},
+		{[]string{"R2"}, 2},
+		{[]string{"R", "U"}, 105},
+		{[]string{"R", "U", "R'", "U'"}, 6},
+		{[]string{"R", "U2", "D'", "B", "D'"}, 1260},
+	}
+
+	for _, tc := range testCases {
+		order, err := cubeAfter(t, tc.moves...).Order()
+		if err != nil {
+			t.Fatalf("Unexpected error for %v: %v", tc.moves, err)
+		}
+
+		if order != tc.expected {
+			t.Errorf("Expected order of %v to be %d, got %d", tc.moves, tc.expected, order)
+		}
+	}
+}
+
+func TestInvalidStateGroupOperations(t *testing.T) {
+	cube := New()
+	cube.Up[0][0] = Yellow
+
+	if _, err := cube.Order(); err == nil {
+		t.Error("Expected error for invalid cube state, got nil")
+	}
+
+	if _, err := cube.Inverse(); err == nil {
+		t.Error("Expected error for invalid cube state, got nil")
+	}
+
+	if _, err := New().Multiply(cube); err == nil {
+		t.Error("Expected error for invalid cube state, got nil")
+	}
+}
@@ -0,0 +1,254 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Stages of the beginner method, which builds the first layer on D and
+// finishes the last layer on U with the 2-look algorithms of the library.
+const (
+	StageCross          = "cross"
+	StageFirstLayer     = "first-layer"
+	StageSecondLayer    = "second-layer"
+	StageLastLayerCross = "last-layer-cross"
+	StageOrientCorners  = "orient-corners"
+	StagePermuteCorners = "permute-corners"
+	StagePermuteEdges   = "permute-edges"
+	StageSolved         = "solved"
+)
+
+// Hint is the next thing to do in the beginner method: Algorithm solves one
+// more piece, or one look of the last layer, with the cube held so that
+// Rotation brings its centers to their usual places.
+type Hint struct {
+	Stage       string    `json:"stage"`
+	Explanation string    `json:"explanation"`
+	Rotation    Algorithm `json:"rotation"`
+	Algorithm   Algorithm `json:"algorithm"`
+	Moves       int       `json:"moves"`
+}
+
+var (
+	firstLayerCorners = []string{"DFR", "DRB", "DBL", "DLF"}
+	middleEdges       = []string{"FR", "RB", "BL", "LF"}
+)
+
+var lastLayerStages = []struct {
+	stage    string
+	category string
+}{
+	{StageLastLayerCross, "eoll"},
+	{StageOrientCorners, "ocll"},
+	{StagePermuteCorners, "cpll"},
+	{StagePermuteEdges, "epll"},
+}
+
+// Hint works out the beginner stage the cube is in and returns the moves
+// that make the next bit of progress, never more than one piece or one
+// last-layer algorithm, so that the rest of the solve is not given away.
+func (c *RubiksCube) Hint(ctx context.Context) (*Hint, error) {
+	p, err := c.permutation()
+	if err != nil {
+		return nil, err
+	}
+
+	held := p.heldRotation()
+	hint, err := p.then(held.perm).nextHint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hint.Rotation = held.alg
+	return hint, nil
+}
+
+// heldRotation is the first rotation that brings the centers of p to their
+// solved places.
+func (p permutation) heldRotation() rotation {
+	for _, r := range rotations {
+		if p.then(r.perm).centersSolved() {
+			return r
+		}
+	}
+	return rotations[0]
+}
+
+// nextHint is Hint for a state with its centers solved.
+func (p permutation) nextHint(ctx context.Context) (*Hint, error) {
+	hint := &Hint{Rotation: Algorithm{}, Algorithm: Algorithm{}}
+	bottom, top := solvedColors[13], solvedColors[4]
+
+	switch {
+	case !p.piecesSolved(crossEdges):
+		piece, alg, err := p.nextPiece(ctx, nil, crossEdges)
+		if err != nil {
+			return nil, err
+		}
+		hint.Stage = StageCross
+		hint.Algorithm = alg
+		hint.Explanation = fmt.Sprintf("Build the %s cross on the bottom: bring the %s edge down so that both of its colors match their centers.", bottom, pieceColors(piece))
+
+	case !p.piecesSolved(firstLayerCorners):
+		piece, alg, err := p.nextPiece(ctx, [][]string{crossEdges}, firstLayerCorners)
+		if err != nil {
+			return nil, err
+		}
+		hint.Stage = StageFirstLayer
+		hint.Algorithm = alg
+		hint.Explanation = fmt.Sprintf("Finish the %s layer: put the %s corner into the bottom corner between the centers of its colors.", bottom, pieceColors(piece))
+
+	case !p.piecesSolved(middleEdges):
+		piece, alg, err := p.nextPiece(ctx, [][]string{crossEdges, firstLayerCorners}, middleEdges)
+		if err != nil {
+			return nil, err
+		}
+		hint.Stage = StageSecondLayer
+		hint.Algorithm = alg
+		hint.Explanation = fmt.Sprintf("Solve the middle layer: put the %s edge between the centers of its colors without breaking the %s layer.", pieceColors(piece), bottom)
+
+	default:
+		hint.Stage = StageSolved
+		hint.Explanation = "The cube is solved."
+		for _, s := range lastLayerStages {
+			category, err := FindLibraryCategory(s.category)
+			if err != nil {
+				return nil, err
+			}
+			if p.reached(category.Goal) {
+				continue
+			}
+
+			alg, err := p.lastLayerStep(category)
+			if err != nil {
+				return nil, err
+			}
+			hint.Stage = s.stage
+			hint.Algorithm = alg
+			hint.Explanation = lastLayerExplanation(s.stage, top)
+			break
+		}
+
+		// Only the top layer is left to turn
+		if hint.Stage == StageSolved && p != identity() {
+			for _, auf := range aufs {
+				if p.then(auf.permutation()) == identity() {
+					hint.Stage = StagePermuteEdges
+					hint.Algorithm = auf
+					hint.Explanation = "Turn the top layer to line it up with the centers and the cube is solved."
+				}
+			}
+		}
+	}
+
+	hint.Moves = len(hint.Algorithm)
+	return hint, nil
+}
+
+func (p permutation) piecesSolved(names []string) bool {
+	for _, f := range pieceFacelets(names) {
+		if p[f] != f {
+			return false
+		}
+	}
+	return true
+}
+
+// nextPiece finds the candidate piece that takes the fewest moves to solve
+// while keeping the groups of pieces and the candidates already solved in
+// place, together with those moves.
+func (p permutation) nextPiece(ctx context.Context, keep [][]string, candidates []string) (string, Algorithm, error) {
+	var kept, done []string
+	var tables []*pruningTable
+	for _, group := range keep {
+		kept = append(kept, group...)
+		tables = append(tables, positionTable(outerFaces, pieceFacelets(group)))
+	}
+	for _, piece := range candidates {
+		if p.piecesSolved([]string{piece}) {
+			done = append(done, piece)
+		}
+	}
+
+	best, bestAlg := "", Algorithm(nil)
+	maxLength := stepMaxLength
+	for _, piece := range candidates {
+		if containsString(done, piece) {
+			continue
+		}
+
+		pieces := append(append([]string{}, done...), piece)
+		goal := pieceFacelets(append(append([]string{}, kept...), pieces...))
+		solved := func(inverse permutation) bool {
+			for _, f := range goal {
+				if inverse[f] != f {
+					return false
+				}
+			}
+			return true
+		}
+
+		alg, err := search(ctx, p, solved, outerFaces, append(tables, positionTable(outerFaces, pieceFacelets(pieces))), maxLength)
+		if ctx.Err() != nil {
+			return "", nil, ctx.Err()
+		}
+		if err != nil {
+			// Nothing shorter than the best piece so far
+			continue
+		}
+		best, bestAlg, maxLength = piece, alg, len(alg)-1
+	}
+
+	if bestAlg == nil {
+		return "", nil, fmt.Errorf("no solution found within %d moves", stepMaxLength)
+	}
+	return best, bestAlg, nil
+}
+
+// lastLayerStep returns the cheapest algorithm of the category, with its
+// AUFs, that brings the cube to the goal of the category. A y rotation in
+// front of it is turned into a relabelling of the moves.
+func (p permutation) lastLayerStep(category *LibraryCategory) (Algorithm, error) {
+	var best *AlgorithmMatch
+	for _, libraryCase := range category.Cases {
+		for _, alg := range libraryCase.Algorithms {
+			match, ok := p.match(alg, category.Goal)
+			if ok && (best == nil || match.Moves < best.Moves) {
+				best = &match
+			}
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("no %s algorithm applies", category.Name)
+	}
+
+	if best.Rotation == "" {
+		return best.Sequence, nil
+	}
+	return best.Sequence[1:].Rotate(best.Rotation)
+}
+
+func lastLayerExplanation(stage string, top Color) string {
+	switch stage {
+	case StageLastLayerCross:
+		return fmt.Sprintf("Make a %s cross on top by flipping the top edges until all of their %s stickers face up.", top, top)
+	case StageOrientCorners:
+		return fmt.Sprintf("Twist the top corners so that the whole top face is %s.", top)
+	case StagePermuteCorners:
+		return "Swap the top corners so that each one sits between the side centers of its colors."
+	}
+	return "Cycle the top edges into place to solve the cube."
+}
+
+// pieceColors names a piece by its colors, starting with the sticker that
+// gives the piece its name.
+func pieceColors(name string) string {
+	i := mustParseSticker(name)
+	colors := []string{string(solvedColors[i])}
+	for _, j := range pieceOf[i] {
+		if j != i {
+			colors = append(colors, string(solvedColors[j]))
+		}
+	}
+	return strings.Join(colors, "-")
+}
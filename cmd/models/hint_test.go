@@ -0,0 +1,92 @@
+package models
+
+import (
+	"context"
+	"testing"
+)
+
+func TestHint(t *testing.T) {
+	testCases := []struct {
+		name             string
+		setup            string
+		expectedStage    string
+		expectedRotation string
+		expectedMoves    int
+	}{
+		{"Solved", "", StageSolved, "", 0},
+		{"Rotated Solved Cube", "x", StageSolved, "x'", 0},
+		{"Cross Edge", "F", StageCross, "", 1},
+		{"First Layer Corner", "R U R' U'", StageFirstLayer, "", 3},
+		{"Middle Layer Edge", "U R U' R' U' F' U F", StageSecondLayer, "", 7},
+		{"Last Layer Cross", "F R U R' U' F'", StageLastLayerCross, "", 7},
+		{"Sune", "R U R' U R U2 R'", StageOrientCorners, "", 7},
+		{"T Permutation", "R U R' U' R' F R2 U' R' U' R U R' F'", StagePermuteCorners, "", 14},
+		{"H Permutation", "M2 U M2 U2 M2 U M2", StagePermuteEdges, "", 7},
+		{"AUF", "U", StagePermuteEdges, "", 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cube := New()
+			cube.ApplyAlgorithm(tc.setup)
+
+			hint, err := cube.Hint(context.Background())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if hint.Stage != tc.expectedStage {
+				t.Errorf("Expected stage %s, got %s", tc.expectedStage, hint.Stage)
+			}
+			if hint.Rotation.String() != tc.expectedRotation {
+				t.Errorf("Expected rotation %q, got %q", tc.expectedRotation, hint.Rotation.String())
+			}
+			if hint.Moves != tc.expectedMoves {
+				t.Errorf("Expected %d moves, got %d (%s)", tc.expectedMoves, hint.Moves, hint.Algorithm)
+			}
+			if hint.Explanation == "" {
+				t.Errorf("Expected an explanation")
+			}
+		})
+	}
+}
+
+func TestHintsSolveCube(t *testing.T) {
+	stages := []string{StageCross, StageFirstLayer, StageSecondLayer, StageLastLayerCross,
+		StageOrientCorners, StagePermuteCorners, StagePermuteEdges, StageSolved}
+	rank := func(stage string) int {
+		for i, s := range stages {
+			if s == stage {
+				return i
+			}
+		}
+		return -1
+	}
+
+	cube := New()
+	cube.ApplyAlgorithm("R2 D' B' L2 F2 U' R2 B2 U' F2 L2 U' F' R U' B D R' F2 D2")
+
+	// Follow the hints: the stages must only move forward until the cube
+	// is solved
+	last := 0
+	for i := 0; i < 30; i++ {
+		hint, err := cube.Hint(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if rank(hint.Stage) < last {
+			t.Fatalf("Stage went back to %s", hint.Stage)
+		}
+		last = rank(hint.Stage)
+
+		if hint.Stage == StageSolved {
+			p, _ := cube.permutation()
+			if p.reoriented() != identity() {
+				t.Errorf("Hint says solved but the cube is not")
+			}
+			return
+		}
+		cube.ApplyAlgorithm(hint.Algorithm.String())
+	}
+	t.Errorf("Hints did not solve the cube")
+}
@@ -0,0 +1,144 @@
+package models
+
+import "fmt"
+
+// LastLayerCase names the last-layer case of a cube whose first two layers
+// are solved. Stage is "oll" while the last layer is not oriented, "pll"
+// once it is and "solved" when only an AUF is left. PreAUF is the U turn to
+// do before the case algorithm and PostAUF the one after it. Subset is the
+// COLL/ZBLL set (H, Pi, U, T, L, S or AS) when all last-layer edges are
+// oriented but the corners are not.
+type LastLayerCase struct {
+	Stage   string `json:"stage"`
+	OLL     int    `json:"oll,omitempty"`
+	PLL     string `json:"pll,omitempty"`
+	Subset  string `json:"subset,omitempty"`
+	PreAUF  string `json:"preAuf"`
+	PostAUF string `json:"postAuf"`
+}
+
+// ocllSubsets names the corner orientation cases that split COLL and ZBLL.
+var ocllSubsets = map[int]string{
+	21: "H", 22: "Pi", 23: "U", 24: "T", 25: "L", 26: "AS", 27: "S",
+}
+
+type lastLayerPattern [faceletCount]bool
+
+type ollCase struct {
+	number  int
+	pattern lastLayerPattern
+}
+
+type pllCase struct {
+	name string
+	perm permutation
+}
+
+var (
+	ollCases = buildOLLCases()
+	pllCases = buildPLLCases()
+)
+
+// buildOLLCases and buildPLLCases take the cases from the algorithm library.
+func buildOLLCases() []ollCase {
+	category, _ := FindLibraryCategory("oll")
+
+	var cases []ollCase
+	for _, c := range category.Cases {
+		cases = append(cases, ollCase{c.Number, c.Setup.permutation().reoriented().orientationPattern()})
+	}
+	return cases
+}
+
+func buildPLLCases() []pllCase {
+	category, _ := FindLibraryCategory("pll")
+
+	var cases []pllCase
+	for _, c := range category.Cases {
+		cases = append(cases, pllCase{c.Name, c.Algorithms[0].permutation()})
+	}
+	return cases
+}
+
+// orientationPattern marks the last-layer stickers that show the U color.
+func (p permutation) orientationPattern() lastLayerPattern {
+	var pattern lastLayerPattern
+	for i := range p {
+		if facelets[i].pos[1] == 1 && facelets[p[i]].normal == faceNormals["U"] {
+			pattern[i] = true
+		}
+	}
+	return pattern
+}
+
+func (p permutation) f2lSolved() bool {
+	for i := range p {
+		if facelets[i].pos[1] < 1 && p[i] != i {
+			return false
+		}
+	}
+	return true
+}
+
+// edgesOriented reports whether every last-layer edge shows the U color on
+// top.
+func (pattern lastLayerPattern) edgesOriented() bool {
+	for i, oriented := range pattern {
+		if isEdge(i) && facelets[i].normal == faceNormals["U"] && !oriented {
+			return false
+		}
+	}
+	return true
+}
+
+// RecognizeLastLayer names the last-layer case of the cube, held with its
+// centers in the solved orientation.
+func (c *RubiksCube) RecognizeLastLayer() (*LastLayerCase, error) {
+	p, err := c.permutation()
+	if err != nil {
+		return nil, err
+	}
+
+	p = p.reoriented()
+	if !p.f2lSolved() {
+		return nil, fmt.Errorf("first two layers are not solved")
+	}
+
+	oriented := identity().orientationPattern()
+	for _, auf := range aufs {
+		pattern := p.then(auf.permutation()).orientationPattern()
+		if pattern == oriented {
+			break
+		}
+
+		for _, oll := range ollCases {
+			if oll.pattern != pattern {
+				continue
+			}
+
+			result := &LastLayerCase{Stage: "oll", OLL: oll.number, PreAUF: auf.String()}
+			if pattern.edgesOriented() {
+				result.Subset = ocllSubsets[oll.number]
+			}
+			return result, nil
+		}
+	}
+
+	for _, auf := range aufs {
+		if p.then(auf.permutation()) == identity() {
+			return &LastLayerCase{Stage: "solved", PostAUF: auf.String()}, nil
+		}
+	}
+
+	for _, pll := range pllCases {
+		for _, pre := range aufs {
+			for _, post := range aufs {
+				if p.then(pre.permutation()).then(pll.perm).then(post.permutation()).reoriented() == identity() {
+					return &LastLayerCase{Stage: "pll", PLL: pll.name, PreAUF: pre.String(), PostAUF: post.String()}, nil
+				}
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("last-layer case not recognized")
+}
@@ -0,0 +1,111 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestRecognizeOLL(t *testing.T) {
+	category, _ := FindLibraryCategory("oll")
+	for _, c := range category.Cases {
+		number, alg := c.Number, c.Algorithms[0]
+		for _, auf := range aufs {
+			// Set up the case, then turn the top
+			cube := New()
+			cube.Apply(c.Setup)
+			cube.Apply(auf)
+
+			result, err := cube.RecognizeLastLayer()
+			if err != nil {
+				t.Fatalf("Unexpected error for OLL %d: %v", number, err)
+			}
+
+			if result.Stage != "oll" || result.OLL != number {
+				t.Errorf("Expected OLL %d after %s, got %+v", number, auf, result)
+				continue
+			}
+
+			cube.ApplyAlgorithm(result.PreAUF)
+			cube.Apply(alg)
+			p, _ := cube.permutation()
+			if p.reoriented().orientationPattern() != identity().orientationPattern() {
+				t.Errorf("Expected pre-AUF %q and OLL %d to orient the last layer", result.PreAUF, number)
+			}
+		}
+	}
+}
+
+func TestRecognizePLL(t *testing.T) {
+	category, _ := FindLibraryCategory("pll")
+	for _, c := range category.Cases {
+		name, alg := c.Name, c.Algorithms[0]
+		for _, pre := range aufs {
+			for _, post := range aufs {
+				// Set up the case and hold the cube with its centers solved
+				setup := append(append(post.Inverse(), c.Setup...), pre.Inverse()...)
+				cube := fromPermutation(setup.permutation().reoriented())
+
+				result, err := cube.RecognizeLastLayer()
+				if err != nil {
+					t.Fatalf("Unexpected error for %s: %v", name, err)
+				}
+
+				if result.Stage != "pll" || result.PLL != name {
+					t.Errorf("Expected PLL %s, got %+v", name, result)
+					continue
+				}
+
+				cube.ApplyAlgorithm(result.PreAUF)
+				cube.Apply(alg)
+				cube.ApplyAlgorithm(result.PostAUF)
+				p, _ := cube.permutation()
+				if p.reoriented() != identity() {
+					t.Errorf("Expected %q %s %q to solve the cube", result.PreAUF, name, result.PostAUF)
+				}
+			}
+		}
+	}
+}
+
+func TestRecognizeSubsets(t *testing.T) {
+	testCases := []struct {
+		setup  string
+		subset string
+	}{
+		{"R U2 R' U' R U' R'", "S"},
+		{"R U R' U R U2 R'", "AS"},
+		{"R U R' U R U' R' U R U2 R'", "H"},
+		{"F R U R' U' F'", ""},
+	}
+
+	for _, tc := range testCases {
+		cube := New()
+		cube.ApplyAlgorithm(tc.setup)
+
+		result, err := cube.RecognizeLastLayer()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.Subset != tc.subset {
+			t.Errorf("Expected subset %q after %s, got %+v", tc.subset, tc.setup, result)
+		}
+	}
+}
+
+func TestRecognizeSolvedAndUnsolvedF2L(t *testing.T) {
+	cube := New()
+	cube.ApplyAlgorithm("U2")
+
+	result, err := cube.RecognizeLastLayer()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Stage != "solved" || result.PostAUF != "U2" {
+		t.Errorf("Expected solved with AUF U2, got %+v", result)
+	}
+
+	cube.ApplyAlgorithm("R")
+	if _, err := cube.RecognizeLastLayer(); err == nil {
+		t.Error("Expected error when the first two layers are not solved")
+	}
+}
@@ -0,0 +1,168 @@
+package models
+
+import "fmt"
+
+// Lesson teaches one step of the beginner method. Setup takes a solved cube
+// to the starting position and the lesson is done once the cube, held with
+// its centers in the usual places, meets the goal.
+type Lesson struct {
+	ID      string    `json:"id"`
+	Title   string    `json:"title"`
+	Goal    string    `json:"goal"`
+	Setup   Algorithm `json:"setup"`
+	reached func(p permutation) bool
+}
+
+var lessons = []Lesson{
+	{
+		ID:    "cross-edge",
+		Title: "Insert a cross edge",
+		Goal:  "Bring the yellow-green edge down so that the yellow cross is complete and every edge matches the center beside it.",
+		Setup: mustParseAlgorithm("F2 U'"),
+		reached: func(p permutation) bool {
+			return p.piecesSolved(crossEdges)
+		},
+	},
+	{
+		ID:    "first-layer-corner",
+		Title: "Insert a first-layer corner",
+		Goal:  "Put the corner above its slot back into the bottom layer to complete the yellow face and the row of stickers around it.",
+		Setup: mustParseAlgorithm("R U R'"),
+		reached: func(p permutation) bool {
+			return p.piecesSolved(crossEdges) && p.piecesSolved(firstLayerCorners)
+		},
+	},
+	{
+		ID:    "second-layer-edge",
+		Title: "Insert a second-layer edge",
+		Goal:  "Move the green-red edge from the top layer into the middle layer without breaking the first layer.",
+		Setup: mustParseAlgorithm("F' U' F U R U R' U'"),
+		reached: func(p permutation) bool {
+			return p.meets("f2l")
+		},
+	},
+	{
+		ID:    "last-layer-cross",
+		Title: "Make the last-layer cross",
+		Goal:  "Flip the top edges until their white stickers form a cross on top.",
+		Setup: mustParseAlgorithm("F R U R' U' F'"),
+		reached: func(p permutation) bool {
+			return p.reached("eo")
+		},
+	},
+	{
+		ID:    "orient-corners",
+		Title: "Orient the last-layer corners",
+		Goal:  "Twist the top corners so that the whole top face is white.",
+		Setup: mustParseAlgorithm("R U2 R' U' R U' R'"),
+		reached: func(p permutation) bool {
+			return p.reached("oll")
+		},
+	},
+	{
+		ID:    "permute-corners",
+		Title: "Permute the last-layer corners",
+		Goal:  "Swap the top corners so that each one sits between the side centers of its colors.",
+		Setup: mustParseAlgorithm("R U R' U' R' F R2 U' R' U' R U R' F'"),
+		reached: func(p permutation) bool {
+			return p.reached("cp")
+		},
+	},
+	{
+		ID:    "permute-edges",
+		Title: "Permute the last-layer edges",
+		Goal:  "Cycle the top edges into place and line up the top layer to solve the cube.",
+		Setup: mustParseAlgorithm("R U' R U R U R U' R' U' R2"),
+		reached: func(p permutation) bool {
+			return p == identity()
+		},
+	},
+}
+
+// Lessons returns the lessons in the order they are meant to be taken.
+func Lessons() []Lesson {
+	return lessons
+}
+
+func FindLesson(id string) (*Lesson, error) {
+	for i := range lessons {
+		if lessons[i].ID == id {
+			return &lessons[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown lesson: %s", id)
+}
+
+// Reached reports whether the cube meets the goal of the lesson.
+func (l *Lesson) Reached(c *RubiksCube) (bool, error) {
+	p, err := c.permutation()
+	if err != nil {
+		return false, err
+	}
+	return l.reached(p.reoriented()), nil
+}
+
+// LessonProgress follows a learner through the lessons. Cube and Moves
+// belong to the current lesson; when a move reaches its goal the lesson is
+// marked completed and the next one is set up, until all are Finished.
+type LessonProgress struct {
+	Lesson    string      `json:"lesson"`
+	Cube      *RubiksCube `json:"cube"`
+	Moves     Algorithm   `json:"moves"`
+	Completed []string    `json:"completed"`
+	Finished  bool        `json:"finished"`
+}
+
+// NewLessonProgress starts with the first lesson.
+func NewLessonProgress() *LessonProgress {
+	progress := &LessonProgress{Completed: []string{}}
+	progress.Start(lessons[0].ID)
+	return progress
+}
+
+// Start sets up the lesson, which may be any of them, on a fresh cube.
+func (lp *LessonProgress) Start(id string) error {
+	lesson, err := FindLesson(id)
+	if err != nil {
+		return err
+	}
+
+	lp.Lesson = lesson.ID
+	lp.Cube = New()
+	lp.Cube.Apply(lesson.Setup)
+	lp.Moves = Algorithm{}
+	lp.Finished = false
+	return nil
+}
+
+// Move performs alg in the current lesson and reports whether it reached
+// the goal, in which case the progress moves on to the next lesson.
+func (lp *LessonProgress) Move(alg Algorithm) (bool, error) {
+	if lp.Finished {
+		return false, fmt.Errorf("all lessons are completed")
+	}
+
+	lesson, err := FindLesson(lp.Lesson)
+	if err != nil {
+		return false, err
+	}
+
+	lp.Cube.Apply(alg)
+	lp.Moves = append(lp.Moves, alg...)
+
+	reached, err := lesson.Reached(lp.Cube)
+	if err != nil || !reached {
+		return false, err
+	}
+
+	if !containsString(lp.Completed, lesson.ID) {
+		lp.Completed = append(lp.Completed, lesson.ID)
+	}
+	for i := range lessons {
+		if lessons[i].ID == lesson.ID && i+1 < len(lessons) {
+			return true, lp.Start(lessons[i+1].ID)
+		}
+	}
+	lp.Finished = true
+	return true, nil
+}
@@ -0,0 +1,78 @@
+package models
+
+import "testing"
+
+func TestLessonSetups(t *testing.T) {
+	for _, lesson := range Lessons() {
+		t.Run(lesson.ID, func(t *testing.T) {
+			cube := New()
+			cube.Apply(lesson.Setup)
+
+			reached, _ := lesson.Reached(cube)
+			if reached {
+				t.Errorf("Setup %s already meets the goal", lesson.Setup)
+			}
+
+			cube.Apply(lesson.Setup.Inverse())
+			reached, _ = lesson.Reached(cube)
+			if !reached {
+				t.Errorf("Undoing the setup does not meet the goal")
+			}
+		})
+	}
+}
+
+func TestLessonProgress(t *testing.T) {
+	progress := NewLessonProgress()
+	if progress.Lesson != "cross-edge" {
+		t.Fatalf("Expected to start with cross-edge, got %s", progress.Lesson)
+	}
+
+	// A move that does not reach the goal stays in the lesson
+	reached, err := progress.Move(mustParseAlgorithm("U"))
+	if err != nil || reached {
+		t.Fatalf("Expected U not to reach the goal, got %v, %v", reached, err)
+	}
+	if progress.Moves.String() != "U" {
+		t.Errorf("Expected moves U, got %s", progress.Moves)
+	}
+
+	// Solving moves on to the next lesson with a fresh cube
+	reached, err = progress.Move(mustParseAlgorithm("F2"))
+	if err != nil || !reached {
+		t.Fatalf("Expected U F2 to reach the goal, got %v, %v", reached, err)
+	}
+	if progress.Lesson != "first-layer-corner" {
+		t.Errorf("Expected first-layer-corner, got %s", progress.Lesson)
+	}
+	if len(progress.Moves) != 0 {
+		t.Errorf("Expected no moves in the new lesson, got %s", progress.Moves)
+	}
+	if len(progress.Completed) != 1 || progress.Completed[0] != "cross-edge" {
+		t.Errorf("Expected cross-edge to be completed, got %v", progress.Completed)
+	}
+
+	// The last lesson finishes the course
+	if err := progress.Start("permute-edges"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	reached, err = progress.Move(mustParseAlgorithm("R U' R U R U R U' R' U' R2").Inverse())
+	if err != nil || !reached {
+		t.Fatalf("Expected the inverse setup to reach the goal, got %v, %v", reached, err)
+	}
+	if !progress.Finished {
+		t.Errorf("Expected all lessons to be finished")
+	}
+	if _, err := progress.Move(mustParseAlgorithm("U")); err == nil {
+		t.Errorf("Expected an error after the last lesson")
+	}
+}
+
+func TestLessonUnknown(t *testing.T) {
+	if _, err := FindLesson("f2l"); err == nil {
+		t.Errorf("Expected an error for an unknown lesson")
+	}
+	if err := NewLessonProgress().Start("f2l"); err == nil {
+		t.Errorf("Expected an error when starting an unknown lesson")
+	}
+}
@@ -0,0 +1,125 @@
+package models
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+)
+
+// LibraryCase is a named case with the algorithms that solve it. Setup
+// creates the case on a solved cube.
+type LibraryCase struct {
+	ID         string      `json:"id"`
+	Name       string      `json:"name"`
+	Number     int         `json:"number,omitempty"`
+	Setup      Algorithm   `json:"setup"`
+	Algorithms []Algorithm `json:"algorithms"`
+}
+
+// LibraryCategory groups cases of one algorithm set. Goal names what its
+// algorithms achieve: "f2l" solves the first two layers, "eo" also orients
+// the last-layer edges, "oll" the whole last layer, "cp" permutes the
+// last-layer corners as well and "solved" solves the cube, up to an AUF.
+type LibraryCategory struct {
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Goal        string        `json:"goal"`
+	Cases       []LibraryCase `json:"cases"`
+}
+
+//go:embed data/library.json
+var libraryData []byte
+
+var library = loadLibrary()
+
+func loadLibrary() []LibraryCategory {
+	var data struct {
+		Categories []LibraryCategory `json:"categories"`
+	}
+	if err := json.Unmarshal(libraryData, &data); err != nil {
+		panic(fmt.Sprintf("invalid algorithm library: %v", err))
+	}
+	return data.Categories
+}
+
+// Library returns every category of the built-in algorithm library.
+func Library() []LibraryCategory {
+	return library
+}
+
+func FindLibraryCategory(id string) (*LibraryCategory, error) {
+	for i := range library {
+		if library[i].ID == id {
+			return &library[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown category: %s", id)
+}
+
+func FindLibraryCase(id string) (*LibraryCase, error) {
+	for i := range library {
+		for j := range library[i].Cases {
+			if library[i].Cases[j].ID == id {
+				return &library[i].Cases[j], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("unknown case: %s", id)
+}
+
+// reached reports whether the state p, held with its centers solved, meets
+// the goal of a library category.
+func (p permutation) reached(goal string) bool {
+	for _, auf := range aufs {
+		if p.then(auf.permutation()).reoriented().meets(goal) {
+			return true
+		}
+	}
+	return false
+}
+
+// meets is reached without the final AUF.
+func (p permutation) meets(goal string) bool {
+	switch goal {
+	case "f2l":
+		return p.f2lSolved()
+	case "eo":
+		return p.f2lSolved() && p.orientationPattern().edgesOriented()
+	case "oll":
+		return p.f2lSolved() && p.orientationPattern() == identity().orientationPattern()
+	case "cp":
+		return p.f2lSolved() && p.orientationPattern() == identity().orientationPattern() && p.cornersSolved()
+	case "solved":
+		return p == identity()
+	}
+	return false
+}
+
+func (p permutation) cornersSolved() bool {
+	for i := range p {
+		if isCorner(i) && p[i] != i {
+			return false
+		}
+	}
+	return true
+}
+
+// SolvedCases lists the library cases that alg solves, allowing an AUF
+// before it.
+func SolvedCases(alg Algorithm) []string {
+	p := alg.permutation()
+
+	var ids []string
+	for _, category := range library {
+		for _, c := range category.Cases {
+			for _, pre := range aufs {
+				if c.Setup.permutation().then(pre.permutation()).then(p).reached(category.Goal) {
+					ids = append(ids, c.ID)
+					break
+				}
+			}
+		}
+	}
+	return ids
+}
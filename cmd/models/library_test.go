@@ -0,0 +1,108 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLibraryContents(t *testing.T) {
+	expected := map[string]int{
+		"f2l":  41,
+		"oll":  57,
+		"pll":  21,
+		"eoll": 3,
+		"ocll": 7,
+		"cpll": 2,
+		"epll": 4,
+	}
+
+	ids := make(map[string]bool)
+	for _, category := range Library() {
+		if len(category.Cases) != expected[category.ID] {
+			t.Errorf("Expected %d cases in %s, got %d", expected[category.ID], category.ID, len(category.Cases))
+		}
+
+		for _, c := range category.Cases {
+			if ids[c.ID] {
+				t.Errorf("Duplicate case id %s", c.ID)
+			}
+			ids[c.ID] = true
+
+			if len(c.Algorithms) == 0 {
+				t.Errorf("Case %s has no algorithms", c.ID)
+			}
+		}
+	}
+}
+
+func TestLibraryAlgorithmsSolveTheirCases(t *testing.T) {
+	for _, category := range Library() {
+		for _, c := range category.Cases {
+			setup := c.Setup.permutation()
+			if setup.reached(category.Goal) {
+				t.Errorf("Setup of %s is already solved", c.ID)
+			}
+
+			for _, alg := range c.Algorithms {
+				solved := false
+				for _, pre := range aufs {
+					if setup.then(pre.permutation()).then(alg.permutation()).reached(category.Goal) {
+						solved = true
+					}
+				}
+				if !solved {
+					t.Errorf("Algorithm %s does not solve %s", alg, c.ID)
+				}
+			}
+		}
+	}
+}
+
+func TestLibraryCasesAreDistinct(t *testing.T) {
+	for _, id := range []string{"f2l", "oll", "pll"} {
+		category, _ := FindLibraryCategory(id)
+		for i, a := range category.Cases {
+			for _, b := range category.Cases[i+1:] {
+				for _, pre := range aufs {
+					if b.Setup.permutation().then(pre.permutation()).then(a.Algorithms[0].permutation()).reached(category.Goal) {
+						t.Errorf("Cases %s and %s are the same", a.ID, b.ID)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestFindLibrary(t *testing.T) {
+	c, err := FindLibraryCase("pll-t")
+	if err != nil || c.Name != "T" {
+		t.Errorf("Expected to find the T perm, got %+v, %v", c, err)
+	}
+
+	if _, err := FindLibraryCase("pll-x"); err == nil {
+		t.Error("Expected error for unknown case")
+	}
+
+	if _, err := FindLibraryCategory("zbll"); err == nil {
+		t.Error("Expected error for unknown category")
+	}
+}
+
+func TestSolvedCases(t *testing.T) {
+	testCases := []struct {
+		algorithm string
+		expected  []string
+	}{
+		{"R U R' U R U2 R'", []string{"oll-27", "ocll-sune"}},
+		{"U R U R' U R U2 R'", []string{"oll-27", "ocll-sune"}},
+		{"R U' R U R U R U' R' U' R2", []string{"pll-ua", "epll-ua"}},
+		{"R2 D2 F2", nil},
+	}
+
+	for _, tc := range testCases {
+		result := SolvedCases(mustParseAlgorithm(tc.algorithm))
+		if !reflect.DeepEqual(result, tc.expected) {
+			t.Errorf("Expected %s to solve %v, got %v", tc.algorithm, tc.expected, result)
+		}
+	}
+}
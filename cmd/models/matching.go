@@ -0,0 +1,100 @@
+package models
+
+import "sort"
+
+// AlgorithmMatch is a library algorithm that brings the cube to the goal of
+// its category. It is performed after Rotation and PreAUF and followed by
+// PostAUF; Sequence spells out all of it.
+type AlgorithmMatch struct {
+	Case      string    `json:"case"`
+	Name      string    `json:"name"`
+	Category  string    `json:"category"`
+	Goal      string    `json:"goal"`
+	Algorithm Algorithm `json:"algorithm"`
+	Rotation  string    `json:"rotation"`
+	PreAUF    string    `json:"preAuf"`
+	PostAUF   string    `json:"postAuf"`
+	Sequence  Algorithm `json:"sequence"`
+	Moves     int       `json:"moves"`
+}
+
+var yRotations = []Algorithm{{}, {{"y", 1}}, {{"y", 2}}, {{"y", 3}}}
+
+// MatchingAlgorithms searches the library for algorithms that solve the
+// cube, held with its centers in the solved orientation. Categories whose
+// goal the cube already meets are skipped. For every algorithm only the
+// cheapest adjustment is kept and matches are sorted by move count.
+func (c *RubiksCube) MatchingAlgorithms() ([]AlgorithmMatch, error) {
+	p, err := c.permutation()
+	if err != nil {
+		return nil, err
+	}
+	p = p.reoriented()
+
+	matches := []AlgorithmMatch{}
+	for _, category := range library {
+		if p.reached(category.Goal) {
+			continue
+		}
+
+		for _, libraryCase := range category.Cases {
+			for _, alg := range libraryCase.Algorithms {
+				match, ok := p.match(alg, category.Goal)
+				if !ok {
+					continue
+				}
+
+				match.Case = libraryCase.ID
+				match.Name = libraryCase.Name
+				match.Category = category.ID
+				match.Goal = category.Goal
+				matches = append(matches, match)
+			}
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Moves != matches[j].Moves {
+			return matches[i].Moves < matches[j].Moves
+		}
+		return len(matches[i].Sequence) < len(matches[j].Sequence)
+	})
+
+	return matches, nil
+}
+
+// match finds the cheapest y rotation, pre-AUF and post-AUF around alg that
+// take the state p to goal.
+func (p permutation) match(alg Algorithm, goal string) (AlgorithmMatch, bool) {
+	algPerm := alg.permutation()
+
+	best := AlgorithmMatch{}
+	bestCost := -1
+	for _, rotation := range yRotations {
+		for _, pre := range aufs {
+			q := p.then(rotation.permutation()).then(pre.permutation()).then(algPerm)
+			for _, post := range aufs {
+				cost := len(rotation) + len(pre) + len(post)
+				if bestCost >= 0 && cost >= bestCost {
+					continue
+				}
+				if !q.then(post.permutation()).reoriented().meets(goal) {
+					continue
+				}
+
+				sequence := append(append(append(append(Algorithm{}, rotation...), pre...), alg...), post...)
+				best = AlgorithmMatch{
+					Algorithm: alg,
+					Rotation:  rotation.String(),
+					PreAUF:    pre.String(),
+					PostAUF:   post.String(),
+					Sequence:  sequence,
+					Moves:     sequence.Metrics().STM,
+				}
+				bestCost = cost
+			}
+		}
+	}
+
+	return best, bestCost >= 0
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMatchingAlgorithms(t *testing.T) {
+	testCases := []struct {
+		name     string
+		setup    string
+		expected string
+	}{
+		{"Sune", "R U2 R' U' R U' R'", "oll-27"},
+		{"Sune With AUF", "R U2 R' U' R U' R' U", "oll-27"},
+		{"T Perm", "R U R' U' R' F R2 U' R' U' R U R' F'", "pll-t"},
+		{"Ua Perm From Behind", "y2 R U' R U R U R U' R' U' R2 y2", "pll-ub"},
+		{"F2L In Back Slot", "y R U R' y'", "f2l-"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cube := New()
+			cube.ApplyAlgorithm(tc.setup)
+
+			matches, err := cube.MatchingAlgorithms()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			found := false
+			for i, match := range matches {
+				if i > 0 && matches[i-1].Moves > match.Moves {
+					t.Errorf("Matches are not sorted by move count")
+				}
+
+				if strings.HasPrefix(match.Case, tc.expected) {
+					found = true
+				}
+
+				// Performing the sequence must reach the goal of the category
+				solved := New()
+				solved.ApplyAlgorithm(tc.setup)
+				solved.Apply(match.Sequence)
+				p, _ := solved.permutation()
+				if !p.reoriented().meets(match.Goal) {
+					t.Errorf("Sequence %s of %s does not reach %s", match.Sequence, match.Case, match.Goal)
+				}
+			}
+
+			if !found {
+				t.Errorf("Expected a match for %s, got %+v", tc.expected, matches)
+			}
+		})
+	}
+}
+
+func TestMatchingAlgorithmsSolved(t *testing.T) {
+	matches, err := New().MatchingAlgorithms()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(matches) != 0 {
+		t.Errorf("Expected no matches on a solved cube, got %d", len(matches))
+	}
+}
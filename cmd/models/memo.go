@@ -0,0 +1,249 @@
+package models
+
+import "fmt"
+
+// MemoOptions configures blindfolded memo. Buffers are sticker names and
+// Lettering overrides the letter of individual stickers, keyed by sticker
+// name; stickers not mentioned keep their Speffz letter.
+type MemoOptions struct {
+	CornerBuffer string
+	EdgeBuffer   string
+	Lettering    map[string]string
+}
+
+type TwistedPiece struct {
+	Piece     string `json:"piece"`
+	Direction string `json:"direction"`
+}
+
+// Memo lists the targets to shoot to from each buffer. The break indices
+// point at the targets that start a new cycle.
+type Memo struct {
+	Corners        []string       `json:"corners"`
+	Edges          []string       `json:"edges"`
+	CornerBreaks   []int          `json:"cornerBreaks"`
+	EdgeBreaks     []int          `json:"edgeBreaks"`
+	TwistedCorners []TwistedPiece `json:"twistedCorners"`
+	FlippedEdges   []string       `json:"flippedEdges"`
+	Parity         bool           `json:"parity"`
+}
+
+const (
+	defaultCornerBuffer = "UBL"
+	defaultEdgeBuffer   = "UR"
+)
+
+var speffzLetters = buildSpeffzLetters()
+
+// buildSpeffzLetters assigns A-X to the corner stickers and again to the edge
+// stickers, four per face in the order U, L, F, R, B, D, going clockwise from
+// the top-left of each face as it appears in the net.
+func buildSpeffzLetters() [faceletCount]string {
+	var letters [faceletCount]string
+	faces := []int{0, 4, 2, 5, 3, 1}
+	corners := [4][2]int{{0, 0}, {0, 2}, {2, 2}, {2, 0}}
+	edges := [4][2]int{{0, 1}, {1, 2}, {2, 1}, {1, 0}}
+
+	for n, face := range faces {
+		for slot := 0; slot < 4; slot++ {
+			letter := string(rune('A' + n*4 + slot))
+			letters[face*9+corners[slot][0]*3+corners[slot][1]] = letter
+			letters[face*9+edges[slot][0]*3+edges[slot][1]] = letter
+		}
+	}
+	return letters
+}
+
+func (o MemoOptions) resolve() (cornerBuffer, edgeBuffer int, letters [faceletCount]string, err error) {
+	letters = speffzLetters
+	for name, letter := range o.Lettering {
+		i, err := ParseSticker(name)
+		if err != nil {
+			return 0, 0, letters, err
+		}
+		letters[i] = letter
+	}
+
+	cornerName, edgeName := o.CornerBuffer, o.EdgeBuffer
+	if cornerName == "" {
+		cornerName = defaultCornerBuffer
+	}
+	if edgeName == "" {
+		edgeName = defaultEdgeBuffer
+	}
+
+	cornerBuffer, err = ParseSticker(cornerName)
+	if err != nil || !isCorner(cornerBuffer) {
+		return 0, 0, letters, fmt.Errorf("invalid corner buffer: %s", cornerName)
+	}
+
+	edgeBuffer, err = ParseSticker(edgeName)
+	if err != nil || !isEdge(edgeBuffer) {
+		return 0, 0, letters, fmt.Errorf("invalid edge buffer: %s", edgeName)
+	}
+
+	return cornerBuffer, edgeBuffer, letters, nil
+}
+
+// Memo works out the blindfolded memo of the cube as it is held with its
+// centers in the solved orientation.
+func (c *RubiksCube) Memo(options MemoOptions) (*Memo, error) {
+	p, err := c.permutation()
+	if err != nil {
+		return nil, err
+	}
+
+	cornerBuffer, edgeBuffer, letters, err := options.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	p = p.reoriented()
+	memo := &Memo{}
+
+	cornerTargets, cornerBreaks := p.trace(cornerBuffer, letters, false)
+	for _, target := range cornerTargets {
+		memo.Corners = append(memo.Corners, letters[target])
+	}
+	memo.CornerBreaks = cornerBreaks
+
+	edgeTargets, edgeBreaks := p.trace(edgeBuffer, letters, false)
+	for _, target := range edgeTargets {
+		memo.Edges = append(memo.Edges, letters[target])
+	}
+	memo.EdgeBreaks = edgeBreaks
+
+	p.swapTargets(cornerBuffer, cornerTargets)
+	p.swapTargets(edgeBuffer, edgeTargets)
+	for _, i := range primaryStickers() {
+		if p[i] == i {
+			continue
+		}
+		if isCorner(i) {
+			memo.TwistedCorners = append(memo.TwistedCorners, TwistedPiece{
+				Piece:     stickerNames[i],
+				Direction: p.twistDirection(i),
+			})
+		} else {
+			memo.FlippedEdges = append(memo.FlippedEdges, stickerNames[i])
+		}
+	}
+
+	memo.Parity = len(cornerTargets)%2 == 1
+	return memo, nil
+}
+
+// trace follows the stickers out of the buffer: each target is where the
+// sticker currently in the buffer belongs. When the buffer holds its own
+// piece the cycle is broken into the unsolved piece with the lowest letter.
+// Pieces that are only twisted or flipped in place are left alone unless
+// includeMisoriented is set.
+func (p permutation) trace(buffer int, letters [faceletCount]string, includeMisoriented bool) (targets []int, breaks []int) {
+	s := p
+	order := stickersByLetter(buffer, letters)
+
+	for {
+		target := s[buffer]
+		if samePiece(target, buffer) {
+			target = -1
+			for _, i := range order {
+				if samePiece(i, buffer) || s.pieceSolved(i) {
+					continue
+				}
+				if !includeMisoriented && samePiece(s[i], i) {
+					continue
+				}
+				target = i
+				break
+			}
+			if target < 0 {
+				return targets, breaks
+			}
+			breaks = append(breaks, len(targets))
+		}
+
+		targets = append(targets, target)
+		s.swapPieces(buffer, target)
+	}
+}
+
+func (p *permutation) swapTargets(buffer int, targets []int) {
+	for _, target := range targets {
+		p.swapPieces(buffer, target)
+	}
+}
+
+// swapPieces exchanges the piece at sticker a with the piece at sticker b,
+// the way a swap algorithm between those two stickers would.
+func (p *permutation) swapPieces(a, b int) {
+	ca, cb := pieceCycle[a], pieceCycle[b]
+	for k := range ca {
+		p[ca[k]], p[cb[k]] = p[cb[k]], p[ca[k]]
+	}
+}
+
+func (p permutation) pieceSolved(i int) bool {
+	for _, j := range pieceOf[i] {
+		if p[j] != j {
+			return false
+		}
+	}
+	return true
+}
+
+// twistDirection tells which way the corner at primary sticker i has been
+// turned in place, seen from outside the cube.
+func (p permutation) twistDirection(i int) string {
+	cycle := pieceCycle[i]
+	if p[cycle[2]] == i {
+		return "clockwise"
+	}
+	return "counterclockwise"
+}
+
+func stickersByLetter(buffer int, letters [faceletCount]string) []int {
+	var order []int
+	for letter := 'A'; letter <= 'Z'; letter++ {
+		for i := range letters {
+			if letters[i] == string(letter) && isCorner(i) == isCorner(buffer) && isEdge(i) == isEdge(buffer) {
+				order = append(order, i)
+			}
+		}
+	}
+	for i := range letters {
+		if !containsInt(order, i) && len(pieceOf[i]) == len(pieceOf[buffer]) {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+// primaryStickers returns one sticker per corner and edge: the one on the U
+// or D face, or on F or B for middle-layer edges.
+func primaryStickers() []int {
+	var result []int
+	for i, name := range stickerNames {
+		if len(name) < 2 {
+			continue
+		}
+		primary := true
+		for _, j := range pieceOf[i] {
+			if faceRank(stickerNames[j][:1]) < faceRank(name[:1]) {
+				primary = false
+			}
+		}
+		if primary {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+func faceRank(letter string) int {
+	for i, l := range faceLetters {
+		if l == letter {
+			return i
+		}
+	}
+	return len(faceLetters)
+}
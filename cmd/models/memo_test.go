@@ -0,0 +1,165 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSpeffzLetters(t *testing.T) {
+	testCases := map[string]string{
+		"UBL": "A", "UBR": "B", "UFR": "C", "UFL": "D",
+		"LUB": "E", "FUL": "I", "RUF": "M", "RDF": "P",
+		"BUR": "Q", "DFL": "U", "DBL": "X",
+		"UB": "A", "UR": "B", "UF": "C", "UL": "D",
+		"LU": "E", "FR": "J", "RF": "P", "BU": "Q", "DF": "U", "DL": "X",
+	}
+
+	for name, expected := range testCases {
+		i, err := ParseSticker(name)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %v", name, err)
+		}
+
+		if speffzLetters[i] != expected {
+			t.Errorf("Expected %s to be lettered %s, got %s", name, expected, speffzLetters[i])
+		}
+	}
+}
+
+func TestMemoSolved(t *testing.T) {
+	memo, err := New().Memo(MemoOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(memo.Corners) != 0 || len(memo.Edges) != 0 || memo.Parity {
+		t.Errorf("Expected empty memo for solved cube, got %+v", memo)
+	}
+}
+
+func TestMemoThreeCycles(t *testing.T) {
+	testCases := []struct {
+		cycle   [3]string
+		corners []string
+		edges   []string
+	}{
+		{[3]string{"UBL", "UFR", "RDF"}, []string{"P", "C"}, nil},
+		{[3]string{"UR", "UF", "FR"}, nil, []string{"J", "C"}},
+	}
+
+	for _, tc := range testCases {
+		cube, err := ThreeCycle(tc.cycle)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		memo, err := cube.Memo(MemoOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(memo.Corners, tc.corners) || !reflect.DeepEqual(memo.Edges, tc.edges) {
+			t.Errorf("Expected memo %v / %v for %v, got %v / %v", tc.corners, tc.edges, tc.cycle, memo.Corners, memo.Edges)
+		}
+
+		if memo.Parity {
+			t.Errorf("Expected no parity for a 3-cycle")
+		}
+	}
+}
+
+func TestMemoCycleBreakAndParity(t *testing.T) {
+	cube := New()
+	cube.ApplyAlgorithm("R U R' U' R' F R2 U' R' U' R U R' F'")
+
+	memo, err := cube.Memo(MemoOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if strings.Join(memo.Edges, "") != "D" {
+		t.Errorf("Expected edge memo D, got %v", memo.Edges)
+	}
+
+	if len(memo.Corners) != 3 || !reflect.DeepEqual(memo.CornerBreaks, []int{0}) {
+		t.Errorf("Expected a cycle break into the UFR/UBR swap, got %v (breaks %v)", memo.Corners, memo.CornerBreaks)
+	}
+
+	if !memo.Parity {
+		t.Error("Expected parity for a single swap")
+	}
+}
+
+func TestMemoTwistsAndFlips(t *testing.T) {
+	cube := New()
+	cube.ApplyAlgorithm("R' D' R D R' D' R D U R' D' R D R' D' R D R' D' R D R' D' R D U'")
+
+	memo, err := cube.Memo(MemoOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(memo.Corners) != 0 || len(memo.TwistedCorners) != 2 {
+		t.Fatalf("Expected two twisted corners and no targets, got %+v", memo)
+	}
+
+	if memo.TwistedCorners[0].Direction == memo.TwistedCorners[1].Direction {
+		t.Errorf("Expected opposite twists, got %+v", memo.TwistedCorners)
+	}
+
+	cube = New()
+	cube.ApplyAlgorithm("M' U M' U M' U M' U2 M' U M' U M' U M'")
+
+	memo, err = cube.Memo(MemoOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(memo.Edges) != 0 || len(memo.FlippedEdges) != 2 {
+		t.Errorf("Expected two flipped edges and no targets, got %+v", memo)
+	}
+}
+
+func TestMemoOptions(t *testing.T) {
+	cube, _ := ThreeCycle([3]string{"UFR", "UBL", "RDF"})
+
+	memo, err := cube.Memo(MemoOptions{
+		CornerBuffer: "UFR",
+		Lettering:    map[string]string{"UBL": "Z"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(memo.Corners, []string{"P", "Z"}) {
+		t.Errorf("Expected memo P Z from the UFR buffer, got %v", memo.Corners)
+	}
+
+	invalid := []MemoOptions{
+		{CornerBuffer: "UF"},
+		{EdgeBuffer: "UFR"},
+		{Lettering: map[string]string{"XYZ": "A"}},
+	}
+	for _, options := range invalid {
+		if _, err := New().Memo(options); err == nil {
+			t.Errorf("Expected error for %+v, got nil", options)
+		}
+	}
+}
+
+func TestMemoIgnoresCubeOrientation(t *testing.T) {
+	cube := New()
+	cube.ApplyAlgorithm("R U R' U' R' F R2 U' R' U' R U R' F'")
+	expected, _ := cube.Memo(MemoOptions{})
+
+	cube.ApplyAlgorithm("x y2")
+	memo, err := cube.Memo(MemoOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(memo, expected) {
+		t.Errorf("Expected the same memo after a rotation, got %+v", memo)
+	}
+}
@@ -0,0 +1,38 @@
+package models
+
+// MoveCount holds the length of a sequence in the common metrics:
+//   - HTM (half turn): any outer or wide turn is 1, a slice is 2, rotations are free
+//   - QTM (quarter turn): like HTM, but half turns count twice
+//   - STM (slice turn): any layer turn, slices included, is 1, rotations are free
+//   - ETM (execution turn): every move, rotations included, is 1
+type MoveCount struct {
+	HTM int `json:"htm"`
+	QTM int `json:"qtm"`
+	STM int `json:"stm"`
+	ETM int `json:"etm"`
+}
+
+func (a Algorithm) Metrics() MoveCount {
+	var count MoveCount
+	for _, turn := range a.normalize() {
+		count.ETM++
+		if turn.IsRotation() {
+			continue
+		}
+
+		quarters := 1
+		if turn.Amount == 2 {
+			quarters = 2
+		}
+
+		layers := 1
+		if turn.IsSlice() {
+			layers = 2
+		}
+
+		count.HTM += layers
+		count.QTM += layers * quarters
+		count.STM++
+	}
+	return count
+}
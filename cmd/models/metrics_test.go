@@ -0,0 +1,25 @@
+package models
+
+import "testing"
+
+func TestMetrics(t *testing.T) {
+	testCases := []struct {
+		notation string
+		expected MoveCount
+	}{
+		{"", MoveCount{}},
+		{"R U R' U'", MoveCount{HTM: 4, QTM: 4, STM: 4, ETM: 4}},
+		{"R2 U2", MoveCount{HTM: 2, QTM: 4, STM: 2, ETM: 2}},
+		{"M2 U M U2 M' U M2", MoveCount{HTM: 11, QTM: 16, STM: 7, ETM: 7}},
+		{"x R2 D2 R U R' D2 R U' R x'", MoveCount{HTM: 9, QTM: 12, STM: 9, ETM: 11}},
+		{"r U R' U' r' F R F'", MoveCount{HTM: 8, QTM: 8, STM: 8, ETM: 8}},
+		{"Rw2 S' y", MoveCount{HTM: 3, QTM: 4, STM: 2, ETM: 3}},
+	}
+
+	for _, tc := range testCases {
+		count := mustParse(t, tc.notation).Metrics()
+		if count != tc.expected {
+			t.Errorf("Expected metrics %+v for %q, got %+v", tc.expected, tc.notation, count)
+		}
+	}
+}
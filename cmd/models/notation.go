@@ -0,0 +1,240 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// NotationError reports where in the input an algorithm could not be read.
+// For malformed brackets the position is that of the opening bracket.
+type NotationError struct {
+	Position int
+	Message  string
+}
+
+func (e *NotationError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Message, e.Position)
+}
+
+// maxExpandedTurns caps how many turns an algorithm may expand to. Every
+// level of nested brackets can double the length, so a short input could
+// otherwise expand to millions of turns.
+const maxExpandedTurns = 5000
+
+type notationParser struct {
+	runes    []rune
+	pos      int
+	niss     bool
+	brackets int
+}
+
+// ParseAlgorithm reads a sequence of moves in standard notation. Face turns
+// (U D F B L R), slices (M E S), wide turns (u or Uw) and rotations (x y z)
+// are accepted, each optionally followed by 2 or a prime. Spaces between
+// moves are optional. Commutators [A, B] expand to A B A' B' and conjugates
+// [A: B] to A B A'; both may be nested, as long as the algorithm expands to
+// at most maxExpandedTurns turns.
+func ParseAlgorithm(notation string) (Algorithm, error) {
+	p := &notationParser{runes: []rune(notation)}
+
+	alg, err := p.parseSequence()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.done() {
+		return nil, &NotationError{p.pos, fmt.Sprintf("unexpected %q", string(p.peek()))}
+	}
+
+	return alg, nil
+}
+
+// ParseNISS reads a fewest-moves solution written with NISS, where moves in
+// parentheses, such as "R (U F)", were found on the inverse scramble. The
+// result is the equivalent solution of the normal scramble: the normal moves
+// in order followed by the inverse of all the moves in parentheses.
+func ParseNISS(notation string) (Algorithm, error) {
+	p := &notationParser{runes: []rune(notation), niss: true}
+
+	normal, inverse := Algorithm{}, Algorithm{}
+	for {
+		alg, err := p.parseSequence()
+		if err != nil {
+			return nil, err
+		}
+		normal = append(normal, alg...)
+
+		if p.done() {
+			break
+		}
+		if p.peek() != '(' {
+			return nil, &NotationError{p.pos, fmt.Sprintf("unexpected %q", string(p.peek()))}
+		}
+
+		start := p.pos
+		p.pos++
+		alg, err = p.parseSequence()
+		if err != nil {
+			return nil, err
+		}
+		if !p.done() && p.peek() == '(' {
+			return nil, &NotationError{p.pos, "nested parenthesis"}
+		}
+		if p.done() || p.peek() != ')' {
+			return nil, &NotationError{start, "unclosed parenthesis"}
+		}
+		p.pos++
+		inverse = append(inverse, alg...)
+		if len(normal)+len(inverse) > maxExpandedTurns {
+			return nil, p.tooLong(start)
+		}
+	}
+
+	return append(normal, inverse.Inverse()...), nil
+}
+
+func (p *notationParser) done() bool {
+	return p.pos >= len(p.runes)
+}
+
+func (p *notationParser) peek() rune {
+	return p.runes[p.pos]
+}
+
+func (p *notationParser) skipSpaces() {
+	for !p.done() && unicode.IsSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+func (p *notationParser) parseSequence() (Algorithm, error) {
+	alg := Algorithm{}
+	for {
+		p.skipSpaces()
+		if p.done() {
+			return alg, nil
+		}
+
+		// Parentheses around NISS segments cannot be inside brackets
+		if p.niss && p.brackets == 0 && (p.peek() == '(' || p.peek() == ')') {
+			return alg, nil
+		}
+
+		switch p.peek() {
+		case ']', ',', ':':
+			return alg, nil
+		case '[':
+			bracket, err := p.parseBracket()
+			if err != nil {
+				return nil, err
+			}
+			alg = append(alg, bracket...)
+		default:
+			turn, err := p.parseTurn()
+			if err != nil {
+				return nil, err
+			}
+			alg = append(alg, turn)
+		}
+
+		if len(alg) > maxExpandedTurns {
+			return nil, p.tooLong(p.pos)
+		}
+	}
+}
+
+func (p *notationParser) parseBracket() (Algorithm, error) {
+	start := p.pos
+	p.pos++
+	p.brackets++
+	defer func() { p.brackets-- }()
+
+	first, err := p.parseSequence()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.done() {
+		return nil, &NotationError{start, "unclosed bracket"}
+	}
+
+	separator := p.peek()
+	if separator == ']' {
+		return nil, &NotationError{start, "bracket is missing ',' or ':'"}
+	}
+	p.pos++
+
+	second, err := p.parseSequence()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.done() {
+		return nil, &NotationError{start, "unclosed bracket"}
+	}
+
+	if p.peek() != ']' {
+		return nil, &NotationError{start, "bracket has more than two parts"}
+	}
+	p.pos++
+
+	if len(first) == 0 || len(second) == 0 {
+		return nil, &NotationError{start, "bracket has an empty part"}
+	}
+
+	length := 2*len(first) + len(second)
+	if separator == ',' {
+		length += len(second)
+	}
+	if length > maxExpandedTurns {
+		return nil, p.tooLong(start)
+	}
+
+	result := append(append(Algorithm{}, first...), second...)
+	result = append(result, first.Inverse()...)
+	if separator == ',' {
+		result = append(result, second.Inverse()...)
+	}
+	return result, nil
+}
+
+func (p *notationParser) tooLong(position int) error {
+	return &NotationError{position, fmt.Sprintf("algorithm expands to more than %d moves", maxExpandedTurns)}
+}
+
+func (p *notationParser) parseTurn() (Turn, error) {
+	start := p.pos
+	face := string(p.peek())
+	if _, ok := turnDefinitions[face]; !ok {
+		return Turn{}, &NotationError{start, fmt.Sprintf("invalid move %q", face)}
+	}
+	p.pos++
+
+	if !p.done() && p.peek() == 'w' && strings.Contains("UDFBLR", face) {
+		face = strings.ToLower(face)
+		p.pos++
+	}
+
+	amount := 1
+	if !p.done() && p.peek() == '2' {
+		amount = 2
+		p.pos++
+	}
+	if !p.done() && isPrime(p.peek()) {
+		if amount == 1 {
+			amount = 3
+		}
+		p.pos++
+	}
+
+	if !p.done() && unicode.IsDigit(p.peek()) {
+		return Turn{}, &NotationError{start, fmt.Sprintf("invalid move %q", string(p.runes[start:p.pos+1]))}
+	}
+
+	return Turn{Face: face, Amount: amount}, nil
+}
+
+func isPrime(r rune) bool {
+	return r == '\'' || r == '’'
+}
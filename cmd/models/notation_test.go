@@ -0,0 +1,170 @@
+package models
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseAlgorithm(t *testing.T) {
+	testCases := []struct {
+		notation string
+		expected string
+	}{
+		{"R U R' U'", "R U R' U'"},
+		{"RUR'U'", "R U R' U'"},
+		{"F2 B2' D’", "F2 B2 D'"},
+		{"Rw U2 r' M E' S2", "r U2 r' M E' S2"},
+		{"x y' z2", "x y' z2"},
+		{"", ""},
+	}
+
+	for _, tc := range testCases {
+		alg, err := ParseAlgorithm(tc.notation)
+		if err != nil {
+			t.Errorf("Unexpected error for %q: %v", tc.notation, err)
+			continue
+		}
+
+		if alg.String() != tc.expected {
+			t.Errorf("Expected %q to parse as %q, got %q", tc.notation, tc.expected, alg.String())
+		}
+	}
+
+	for _, notation := range []string{"X", "R3", "Mw", "R'2", "R U Q"} {
+		if _, err := ParseAlgorithm(notation); err == nil {
+			t.Errorf("Expected error for %q, got nil", notation)
+		}
+	}
+}
+
+func TestParseCommutatorsAndConjugates(t *testing.T) {
+	testCases := []struct {
+		notation string
+		expected string
+	}{
+		{"[R U R', D]", "R U R' D R U' R' D'"},
+		{"[U: R' D R]", "U R' D R U'"},
+		{"[U: [R' D R, U2]]", "U R' D R U2 R' D' R U2 U'"},
+		{"[[R, U]: F]", "R U R' U' F U R U' R'"},
+		{"F [R, U] F'", "F R U R' U' F'"},
+		{"[R U,D][U:R]", "R U D U' R' D' U R U'"},
+	}
+
+	for _, tc := range testCases {
+		alg, err := ParseAlgorithm(tc.notation)
+		if err != nil {
+			t.Errorf("Unexpected error for %q: %v", tc.notation, err)
+			continue
+		}
+
+		if alg.String() != tc.expected {
+			t.Errorf("Expected %q to expand to %q, got %q", tc.notation, tc.expected, alg.String())
+		}
+	}
+}
+
+func TestCommutatorApplied(t *testing.T) {
+	cube := New()
+	if err := cube.ApplyAlgorithm("[R U R', D]"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := New()
+	expected.ApplyAlgorithm("R U R' D R U' R' D'")
+
+	if !reflect.DeepEqual(cube, expected) {
+		t.Error("Applying a commutator differs from applying its expansion")
+	}
+}
+
+func TestMalformedBrackets(t *testing.T) {
+	testCases := []struct {
+		notation string
+		position int
+		message  string
+	}{
+		{"[R U R', D", 0, "unclosed bracket at position 0"},
+		{"R [U: R' D R", 2, "unclosed bracket at position 2"},
+		{"[R, U] [F R]", 7, "bracket is missing ',' or ':' at position 7"},
+		{"[R, U: F]", 0, "bracket has more than two parts at position 0"},
+		{"[U: [R, ]]", 4, "bracket has an empty part at position 4"},
+		{"R U] F", 3, "unexpected \"]\" at position 3"},
+		{"[R, Q]", 4, "invalid move \"Q\" at position 4"},
+		{strings.Repeat("[", 22) + "R, U" + strings.Repeat("], U", 21) + "]", 11, "algorithm expands to more than 5000 moves at position 11"},
+		{strings.Repeat("R ", maxExpandedTurns+1), 2*maxExpandedTurns + 1, "algorithm expands to more than 5000 moves at position 10001"},
+	}
+
+	for _, tc := range testCases {
+		_, err := ParseAlgorithm(tc.notation)
+
+		var notationErr *NotationError
+		if !errors.As(err, &notationErr) {
+			t.Errorf("Expected a NotationError for %q, got %v", tc.notation, err)
+			continue
+		}
+
+		if notationErr.Position != tc.position || err.Error() != tc.message {
+			t.Errorf("Expected %q for %q, got %q", tc.message, tc.notation, err.Error())
+		}
+	}
+}
+
+func TestParseNISS(t *testing.T) {
+	testCases := []struct {
+		notation string
+		expected string
+	}{
+		{"R U F", "R U F"},
+		{"F' (R U)", "F' U' R'"},
+		{"(R) U (F2 L') D", "U D L F2 R'"},
+		{"(R U R')", "R U' R'"},
+		{"([R, U]) F", "F U R U' R'"},
+		{"", ""},
+	}
+
+	for _, tc := range testCases {
+		alg, err := ParseNISS(tc.notation)
+		if err != nil {
+			t.Errorf("Unexpected error for %q: %v", tc.notation, err)
+			continue
+		}
+
+		if alg.String() != tc.expected {
+			t.Errorf("Expected %q to parse as %q, got %q", tc.notation, tc.expected, alg.String())
+		}
+	}
+
+	// Moves found on the inverse scramble solve the normal one
+	scramble := mustParseAlgorithm("R U F D2 L'")
+	solution, _ := ParseNISS("L D2 (R U) F'")
+	if !VerifySolution(scramble, solution) {
+		t.Errorf("Expected %s to solve %s", solution, scramble)
+	}
+
+	// Without NISS, parentheses are not part of the notation
+	if _, err := ParseAlgorithm("(R U)"); err == nil {
+		t.Errorf("Expected an error for parentheses outside NISS")
+	}
+}
+
+func TestMalformedNISS(t *testing.T) {
+	testCases := []struct {
+		notation string
+		message  string
+	}{
+		{"R (U F", "unclosed parenthesis at position 2"},
+		{"R (U (F))", "nested parenthesis at position 5"},
+		{"R U) F", "unexpected \")\" at position 3"},
+		{"[R, (U)]", "invalid move \"(\" at position 4"},
+		{"(R Q)", "invalid move \"Q\" at position 3"},
+	}
+
+	for _, tc := range testCases {
+		_, err := ParseNISS(tc.notation)
+		if err == nil || err.Error() != tc.message {
+			t.Errorf("Expected %q for %q, got %v", tc.message, tc.notation, err)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Stickers are named by the face they are on followed by the other faces of
+// their piece, e.g. "FUR" is the front sticker of the up-front-right corner.
+// The remaining faces may be given in any order when parsing.
+
+var (
+	faceNormals = map[string]vec{
+		"U": {0, 1, 0},
+		"D": {0, -1, 0},
+		"F": {0, 0, 1},
+		"B": {0, 0, -1},
+		"L": {-1, 0, 0},
+		"R": {1, 0, 0},
+	}
+	faceLetters  = []string{"U", "D", "F", "B", "L", "R"}
+	stickerNames = buildStickerNames()
+	stickerIndex = buildStickerIndex()
+	pieceCycle   = buildPieceCycles()
+)
+
+func faceLetter(normal vec) string {
+	for _, letter := range faceLetters {
+		if faceNormals[letter] == normal {
+			return letter
+		}
+	}
+	return ""
+}
+
+func buildStickerNames() [faceletCount]string {
+	var names [faceletCount]string
+	for i, f := range facelets {
+		own := faceLetter(f.normal)
+		name := own
+		for _, letter := range faceLetters {
+			if letter != own && dot(faceNormals[letter], f.pos) == 1 {
+				name += letter
+			}
+		}
+		names[i] = name
+	}
+	return names
+}
+
+func stickerKey(name string) string {
+	rest := strings.Split(name[1:], "")
+	sort.Strings(rest)
+	return name[:1] + strings.Join(rest, "")
+}
+
+func buildStickerIndex() map[string]int {
+	index := make(map[string]int)
+	for i, name := range stickerNames {
+		index[stickerKey(name)] = i
+	}
+	return index
+}
+
+// buildPieceCycles orders the stickers of every piece the same way around
+// it, so that moving a piece from one place to another maps the n-th sticker
+// of one onto the n-th sticker of the other.
+func buildPieceCycles() [faceletCount][]int {
+	var cycles [faceletCount][]int
+	for i := range facelets {
+		piece := append([]int{}, pieceOf[i]...)
+		if len(piece) == 3 {
+			a, b, c := facelets[piece[0]], facelets[piece[1]], facelets[piece[2]]
+			if dot(cross(a.normal, b.normal), c.normal) < 0 {
+				piece[1], piece[2] = piece[2], piece[1]
+			}
+		}
+		for len(piece) > 0 && piece[0] != i {
+			piece = append(piece[1:], piece[0])
+		}
+		cycles[i] = piece
+	}
+	return cycles
+}
+
+func cross(a, b vec) vec {
+	return vec{a[1]*b[2] - a[2]*b[1], a[2]*b[0] - a[0]*b[2], a[0]*b[1] - a[1]*b[0]}
+}
+
+func dot(a, b vec) int {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
+// ParseSticker returns the facelet index of a corner or edge sticker name.
+func ParseSticker(name string) (int, error) {
+	upper := strings.ToUpper(name)
+	if len(upper) < 2 || len(upper) > 3 {
+		return 0, fmt.Errorf("invalid sticker: %s", name)
+	}
+
+	i, ok := stickerIndex[stickerKey(upper)]
+	if !ok {
+		return 0, fmt.Errorf("invalid sticker: %s", name)
+	}
+	return i, nil
+}
+
+func StickerName(i int) string {
+	return stickerNames[i]
+}
+
+func isCorner(i int) bool {
+	return len(pieceOf[i]) == 3
+}
+
+func isEdge(i int) bool {
+	return len(pieceOf[i]) == 2
+}
+
+func samePiece(a, b int) bool {
+	return facelets[a].pos == facelets[b].pos
+}
+
+// movePiece records in p that the piece whose sticker belongs at from now
+// sits so that this sticker is at to.
+func (p *permutation) movePiece(from, to int) {
+	source, target := pieceCycle[from], pieceCycle[to]
+	for k := range source {
+		p[target[k]] = source[k]
+	}
+}
+
+// ThreeCycle returns the state in which the piece at the first sticker has
+// moved to the second, the one at the second to the third and the one at
+// the third back to the first. All three must be corners or all edges.
+func ThreeCycle(stickers [3]string) (*RubiksCube, error) {
+	var indices [3]int
+	for i, name := range stickers {
+		index, err := ParseSticker(name)
+		if err != nil {
+			return nil, err
+		}
+		indices[i] = index
+	}
+
+	a, b, c := indices[0], indices[1], indices[2]
+	if !(isCorner(a) && isCorner(b) && isCorner(c)) && !(isEdge(a) && isEdge(b) && isEdge(c)) {
+		return nil, fmt.Errorf("stickers must be all corners or all edges")
+	}
+
+	if samePiece(a, b) || samePiece(b, c) || samePiece(a, c) {
+		return nil, fmt.Errorf("stickers must belong to three different pieces")
+	}
+
+	p := identity()
+	p.movePiece(a, b)
+	p.movePiece(b, c)
+	p.movePiece(c, a)
+	return fromPermutation(p), nil
+}
@@ -0,0 +1,268 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+)
+
+const (
+	SubsetFull      = "full"
+	SubsetLastLayer = "ll"
+	SubsetZBLL      = "zbll"
+	SubsetPLL       = "pll"
+	SubsetLSE       = "lse"
+	SubsetEdges     = "edges"
+)
+
+// Scramble is a uniformly random state of a subset together with a
+// sequence that takes a solved cube to it. Rejected counts the states drawn
+// before it that did not meet the constraints.
+type Scramble struct {
+	Subset   string      `json:"subset"`
+	Scramble Algorithm   `json:"scramble"`
+	Moves    int         `json:"moves"`
+	Cube     *RubiksCube `json:"cube"`
+	Rejected int         `json:"rejected"`
+}
+
+// ScrambleConstraints keep states that would make an easy practice solve
+// out of the scrambles: an optimal D cross shorter than MinCrossLength, an
+// F2L pair that is already connected or in its slot, or a last layer whose
+// U face is already one color. States are drawn again up to MaxAttempts
+// times, 1000 by default.
+type ScrambleConstraints struct {
+	MinCrossLength      int
+	NoSolvedPairs       bool
+	NoOrientedLastLayer bool
+	MaxAttempts         int
+}
+
+const defaultScrambleAttempts = 1000
+
+// ErrConstraintsNotMet is returned when no state drawn for a scramble meets
+// its constraints.
+var ErrConstraintsNotMet = errors.New("no state met the constraints")
+
+// scrambleSubset describes the states of a subset: the pieces in the corner
+// and edge places are shuffled among each other, twisted and flipped when
+// twist and flip are set. A subset with a move set is instead the group
+// those moves generate.
+type scrambleSubset struct {
+	corners []string
+	edges   []string
+	twist   bool
+	flip    bool
+	moveSet []string
+}
+
+var (
+	allCorners       = []string{"UFR", "URB", "UBL", "ULF", "DFR", "DRB", "DBL", "DLF"}
+	allEdges         = []string{"UF", "UR", "UB", "UL", "DF", "DR", "DB", "DL", "FR", "FL", "BR", "BL"}
+	lastLayerCorners = []string{"UFR", "URB", "UBL", "ULF"}
+	lastLayerEdges   = []string{"UF", "UR", "UB", "UL"}
+)
+
+var scrambleSubsets = map[string]scrambleSubset{
+	SubsetFull:      {corners: allCorners, edges: allEdges, twist: true, flip: true},
+	SubsetLastLayer: {corners: lastLayerCorners, edges: lastLayerEdges, twist: true, flip: true},
+	SubsetZBLL:      {corners: lastLayerCorners, edges: lastLayerEdges, twist: true},
+	SubsetPLL:       {corners: lastLayerCorners, edges: lastLayerEdges},
+	SubsetLSE:       {moveSet: []string{"M", "U"}},
+	SubsetEdges:     {edges: allEdges, flip: true},
+}
+
+// GenerateScramble draws a state of the subset uniformly at random among
+// those that meet the constraints and finds a scramble for it. LSE scrambles
+// use M and U; all others undo a two-phase solve, so they have at most
+// twoPhaseMaxLength outer turns.
+func GenerateScramble(ctx context.Context, subset string, constraints ScrambleConstraints, rng *rand.Rand) (*Scramble, error) {
+	s, ok := scrambleSubsets[subset]
+	if !ok {
+		return nil, fmt.Errorf("invalid subset: %s", subset)
+	}
+
+	attempts := constraints.MaxAttempts
+	if attempts <= 0 {
+		attempts = defaultScrambleAttempts
+	}
+
+	var p permutation
+	rejected := 0
+	for {
+		p = s.random(rng)
+		ok, err := constraints.allow(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			break
+		}
+
+		rejected++
+		if rejected == attempts {
+			return nil, fmt.Errorf("%w in %d attempts of %s", ErrConstraintsNotMet, attempts, subset)
+		}
+	}
+
+	var scramble Algorithm
+	if s.moveSet != nil {
+		solution, err := fromPermutation(p).Solve(ctx, SolveOptions{Faces: s.moveSet})
+		if err != nil {
+			return nil, err
+		}
+		scramble = solution.Algorithm.Inverse()
+	} else {
+		solution, err := solveTwoPhase(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		scramble = solution.Inverse()
+	}
+
+	if identity().then(scramble.permutation()) != p {
+		return nil, fmt.Errorf("scramble does not reach the state")
+	}
+
+	return &Scramble{
+		Subset:   subset,
+		Scramble: scramble,
+		Moves:    len(scramble),
+		Cube:     fromPermutation(p),
+		Rejected: rejected,
+	}, nil
+}
+
+// allow reports whether the state p meets the constraints, looking at it
+// with its centers solved.
+func (sc ScrambleConstraints) allow(ctx context.Context, p permutation) (bool, error) {
+	p = p.then(p.heldRotation().perm)
+
+	if sc.NoOrientedLastLayer && p.topOriented() {
+		return false, nil
+	}
+	if sc.NoSolvedPairs && p.hasConnectedPair() {
+		return false, nil
+	}
+
+	if sc.MinCrossLength > 0 {
+		goal := pieceFacelets(crossEdges)
+		solved := func(inverse permutation) bool {
+			for _, f := range goal {
+				if inverse[f] != f {
+					return false
+				}
+			}
+			return true
+		}
+
+		// Any cross within fewer moves is too short
+		tables := []*pruningTable{positionTable(outerFaces, goal)}
+		_, err := search(ctx, p, solved, outerFaces, tables, sc.MinCrossLength-1)
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		if err == nil {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// topOriented reports whether the U face is all one color.
+func (p permutation) topOriented() bool {
+	for i := 0; i < 9; i++ {
+		if solvedColors[p[i]] != solvedColors[4] {
+			return false
+		}
+	}
+	return true
+}
+
+// hasConnectedPair reports whether an F2L corner and edge sit next to each
+// other the way they do in their slot, wherever that is.
+func (p permutation) hasConnectedPair() bool {
+	inverse := p.inverse()
+	for i, corner := range firstLayerCorners {
+		edge := pieceOf[mustParseSticker(middleEdges[i])]
+
+		connected := true
+		for _, c := range pieceOf[mustParseSticker(corner)] {
+			if stickerNames[c][0] == 'D' {
+				continue
+			}
+			for _, e := range edge {
+				if stickerNames[e][0] == stickerNames[c][0] && !adjacent(inverse[c], inverse[e]) {
+					connected = false
+				}
+			}
+		}
+		if connected {
+			return true
+		}
+	}
+	return false
+}
+
+// adjacent reports whether two facelets are side by side on one face.
+func adjacent(a, b int) bool {
+	if facelets[a].normal != facelets[b].normal {
+		return false
+	}
+	distance := 0
+	for k := range facelets[a].pos {
+		d := facelets[a].pos[k] - facelets[b].pos[k]
+		if d < 0 {
+			d = -d
+		}
+		distance += d
+	}
+	return distance == 1
+}
+
+// random draws a state of the subset. Shuffled pieces give every state the
+// same chance and states that cannot be reached by turning are drawn again.
+func (s scrambleSubset) random(rng *rand.Rand) permutation {
+	if s.moveSet != nil {
+		return generatedSubgroup(s.moveSet).random(rng)
+	}
+
+	cube := generatedSubgroup(outerFaces)
+	for {
+		p := identity()
+		p.shufflePieces(s.corners, s.twist, rng)
+		p.shufflePieces(s.edges, s.flip, rng)
+		if cube.contains(p) {
+			return p
+		}
+	}
+}
+
+func (p *permutation) shufflePieces(places []string, orient bool, rng *rand.Rand) {
+	order := rng.Perm(len(places))
+	for i, place := range places {
+		from := pieceCycle[mustParseSticker(places[order[i]])]
+		k := 0
+		if orient {
+			k = rng.Intn(len(from))
+		}
+		p.movePiece(from[k], mustParseSticker(place))
+	}
+}
+
+// random returns an element of the subgroup with every element equally
+// likely, as the product of one random transversal element per level.
+func (g *subgroup) random(rng *rand.Rand) permutation {
+	p := identity()
+	for k := range g.transversal {
+		var choices []*permutation
+		for _, u := range g.transversal[k] {
+			if u != nil {
+				choices = append(choices, u)
+			}
+		}
+		p = p.then(*choices[rng.Intn(len(choices))])
+	}
+	return p
+}
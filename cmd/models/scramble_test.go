@@ -0,0 +1,167 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestGenerateScramble(t *testing.T) {
+	testCases := []struct {
+		subset   string
+		inSubset func(p permutation) bool
+	}{
+		{SubsetFull, func(p permutation) bool { return p.centersSolved() }},
+		{SubsetLastLayer, func(p permutation) bool { return p.meets("f2l") }},
+		{SubsetZBLL, func(p permutation) bool { return p.reached("eo") }},
+		{SubsetPLL, func(p permutation) bool { return p.reached("oll") }},
+		{SubsetLSE, func(p permutation) bool { return generatedSubgroup([]string{"M", "U"}).contains(p) }},
+		{SubsetEdges, func(p permutation) bool { return p.centersSolved() && p.cornersSolved() }},
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for _, tc := range testCases {
+		t.Run(tc.subset, func(t *testing.T) {
+			scramble, err := GenerateScramble(context.Background(), tc.subset, ScrambleConstraints{}, rng)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			p, _ := scramble.Cube.permutation()
+			if p == identity() {
+				t.Errorf("Expected a scrambled cube")
+			}
+			if !tc.inSubset(p) {
+				t.Errorf("State is not in %s", tc.subset)
+			}
+
+			cube := New()
+			cube.Apply(scramble.Scramble)
+			if *cube != *scramble.Cube {
+				t.Errorf("Scramble %s does not reach the state", scramble.Scramble)
+			}
+			if scramble.Moves != len(scramble.Scramble) {
+				t.Errorf("Expected %d moves, got %d", len(scramble.Scramble), scramble.Moves)
+			}
+
+			if tc.subset != SubsetLSE && scramble.Moves > twoPhaseMaxLength {
+				t.Errorf("Expected at most %d moves, got %d", twoPhaseMaxLength, scramble.Moves)
+			}
+			for _, turn := range scramble.Scramble {
+				if tc.subset != SubsetLSE && !containsString(outerFaces, turn.Face) {
+					t.Errorf("Expected outer turns only, got %s", turn)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateScrambleCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := GenerateScramble(ctx, SubsetFull, ScrambleConstraints{}, rand.New(rand.NewSource(2))); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the scramble to stop with the context, got %v", err)
+	}
+}
+
+func BenchmarkGenerateScrambleFull(b *testing.B) {
+	PrepareTwoPhase()
+
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < b.N; i++ {
+		if _, err := GenerateScramble(context.Background(), SubsetFull, ScrambleConstraints{}, rng); err != nil {
+			b.Fatalf("Unexpected error: %v", err)
+		}
+	}
+}
+
+func TestGenerateScrambleInvalid(t *testing.T) {
+	if _, err := GenerateScramble(context.Background(), "oll", ScrambleConstraints{}, rand.New(rand.NewSource(1))); err == nil {
+		t.Errorf("Expected an error for an unknown subset")
+	}
+}
+
+func TestScrambleConstraints(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	constraints := ScrambleConstraints{MinCrossLength: 6, NoSolvedPairs: true}
+	for i := 0; i < 3; i++ {
+		scramble, err := GenerateScramble(context.Background(), SubsetFull, constraints, rng)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		p, _ := scramble.Cube.permutation()
+		if p.hasConnectedPair() {
+			t.Errorf("Scramble %s has a connected pair", scramble.Scramble)
+		}
+
+		goal := pieceFacelets(crossEdges)
+		solved := func(inverse permutation) bool {
+			for _, f := range goal {
+				if inverse[f] != f {
+					return false
+				}
+			}
+			return true
+		}
+		if alg, err := search(context.Background(), p, solved, outerFaces, []*pruningTable{positionTable(outerFaces, goal)}, 5); err == nil {
+			t.Errorf("Scramble %s has a %d move cross: %s", scramble.Scramble, len(alg), alg)
+		}
+	}
+
+	scramble, err := GenerateScramble(context.Background(), SubsetLastLayer, ScrambleConstraints{NoOrientedLastLayer: true}, rng)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if p, _ := scramble.Cube.permutation(); p.topOriented() {
+		t.Errorf("Scramble %s has an oriented last layer", scramble.Scramble)
+	}
+
+	// Every PLL state has its last layer oriented
+	constraints = ScrambleConstraints{NoOrientedLastLayer: true, MaxAttempts: 10}
+	scramble, err = GenerateScramble(context.Background(), SubsetPLL, constraints, rng)
+	if !errors.Is(err, ErrConstraintsNotMet) {
+		t.Errorf("Expected ErrConstraintsNotMet, got %v", err)
+	}
+}
+
+func TestConnectedPairs(t *testing.T) {
+	testCases := []struct {
+		alg       string
+		connected bool
+	}{
+		{"", true},
+		{"R U R' U'", true},
+		{"R2 L2 U2 D2 F2 B2", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.alg, func(t *testing.T) {
+			p := identity().then(mustParseAlgorithm(tc.alg).permutation())
+			if p.hasConnectedPair() != tc.connected {
+				t.Errorf("Expected connected pair %v after %s", tc.connected, tc.alg)
+			}
+		})
+	}
+}
+
+func TestScrambleSubsetIsUniform(t *testing.T) {
+	// PLL states with F2L solved are the 288 permutations of the last
+	// layer, including the AUF; each should come up about equally often
+	rng := rand.New(rand.NewSource(1))
+	counts := make(map[permutation]int)
+	for i := 0; i < 288*50; i++ {
+		counts[scrambleSubsets[SubsetPLL].random(rng)]++
+	}
+
+	if len(counts) != 288 {
+		t.Fatalf("Expected 288 states, got %d", len(counts))
+	}
+	for _, n := range counts {
+		if n < 20 || n > 90 {
+			t.Errorf("State drawn %d times, expected about 50", n)
+		}
+	}
+}
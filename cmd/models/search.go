@@ -0,0 +1,219 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SearchOptions configures an algorithm search. Faces is the move set, such
+// as R and U for <R,U>; every face may be turned a quarter, half or inverse
+// quarter turn. Goal is a library goal ("f2l", "eo", "oll", "cp") or
+// "solved", the default. Workers defaults to the number of CPUs.
+type SearchOptions struct {
+	Faces     []string
+	MaxLength int
+	Goal      string
+	Workers   int
+}
+
+// searchCheckInterval is the number of nodes a worker visits between checks
+// for cancellation.
+const searchCheckInterval = 1 << 12
+
+// ParseMoveSet reads a move-set restriction such as "<R,U,F>", "R,U" or
+// "RUF" into its faces.
+func ParseMoveSet(moveSet string) ([]string, error) {
+	moveSet = strings.TrimSpace(moveSet)
+	moveSet = strings.TrimSuffix(strings.TrimPrefix(moveSet, "<"), ">")
+
+	var names []string
+	if strings.ContainsAny(moveSet, ", ") {
+		names = strings.FieldsFunc(moveSet, func(r rune) bool { return r == ',' || r == ' ' })
+	} else {
+		for _, r := range moveSet {
+			names = append(names, string(r))
+		}
+	}
+
+	if err := checkMoveSet(names); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+func checkMoveSet(faces []string) error {
+	for i, face := range faces {
+		if _, ok := turnDefinitions[face]; !ok || strings.ContainsAny(face, "xyz") {
+			return fmt.Errorf("invalid move set: unknown move %s", face)
+		}
+		if containsString(faces[:i], face) {
+			return fmt.Errorf("invalid move set: %s appears twice", face)
+		}
+	}
+
+	if len(faces) == 0 {
+		return fmt.Errorf("invalid move set: no moves")
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// SearchAlgorithms enumerates every sequence of up to MaxLength turns of the
+// move set that brings the cube, as it is held now, to the goal. Sequences
+// are never extended past a solution and are deduplicated by simplification.
+// The search is split across workers by its first two turns; when ctx is
+// cancelled the solutions found so far are returned with the context error.
+func (c *RubiksCube) SearchAlgorithms(ctx context.Context, options SearchOptions) ([]Algorithm, error) {
+	p, err := c.permutation()
+	if err != nil {
+		return nil, err
+	}
+
+	goal := options.Goal
+	if goal == "" {
+		goal = "solved"
+	}
+	if !containsString([]string{"f2l", "eo", "oll", "cp", "solved"}, goal) {
+		return nil, fmt.Errorf("invalid goal: %s", goal)
+	}
+
+	if err := checkMoveSet(options.Faces); err != nil {
+		return nil, err
+	}
+	if options.MaxLength < 1 {
+		return nil, fmt.Errorf("max length must be at least 1")
+	}
+
+	workers := options.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	if p.reoriented().meets(goal) {
+		return []Algorithm{{}}, nil
+	}
+
+	s := &searcher{
+		ctx:       ctx,
+		goal:      goal,
+		faces:     options.Faces,
+		maxLength: options.MaxLength,
+		seen:      make(map[string]bool),
+	}
+
+	// Each job is a first turn, or the first two turns when the first one
+	// leaves something to search.
+	var jobs []Algorithm
+	for _, first := range canonicalSequences(options.Faces, 1)[1:] {
+		if options.MaxLength == 1 || p.then(first.permutation()).reoriented().meets(goal) {
+			jobs = append(jobs, first)
+			continue
+		}
+		for _, second := range canonicalSequences(options.Faces, 1)[1:] {
+			if canFollow(first[0], second[0]) {
+				jobs = append(jobs, Algorithm{first[0], second[0]})
+			}
+		}
+	}
+
+	queue := make(chan Algorithm)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range queue {
+				nodes := 0
+				s.visit(p.then(job.permutation()), job, &nodes)
+			}
+		}()
+	}
+
+feed:
+	for _, job := range jobs {
+		select {
+		case queue <- job:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	sort.Slice(s.results, func(i, j int) bool {
+		if len(s.results[i]) != len(s.results[j]) {
+			return len(s.results[i]) < len(s.results[j])
+		}
+		return s.results[i].String() < s.results[j].String()
+	})
+
+	return s.results, ctx.Err()
+}
+
+type searcher struct {
+	ctx       context.Context
+	goal      string
+	faces     []string
+	maxLength int
+
+	mutex   sync.Mutex
+	seen    map[string]bool
+	results []Algorithm
+}
+
+// visit records sequence when it takes the cube to the goal and otherwise
+// extends it by every turn that may follow. It returns false once the search
+// is cancelled.
+func (s *searcher) visit(p permutation, sequence Algorithm, nodes *int) bool {
+	*nodes++
+	if *nodes%searchCheckInterval == 0 && s.ctx.Err() != nil {
+		return false
+	}
+
+	if p.reoriented().meets(s.goal) {
+		s.record(sequence)
+		return true
+	}
+	if len(sequence) == s.maxLength {
+		return true
+	}
+
+	last := sequence[len(sequence)-1]
+	for _, face := range s.faces {
+		for amount := 1; amount <= 3; amount++ {
+			turn := Turn{Face: face, Amount: amount}
+			if !canFollow(last, turn) {
+				continue
+			}
+			if !s.visit(p.then(turnPermutations[turn]), append(sequence, turn), nodes) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (s *searcher) record(sequence Algorithm) {
+	alg := sequence.Simplify().Algorithm
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.seen[alg.String()] {
+		return
+	}
+	s.seen[alg.String()] = true
+	s.results = append(s.results, alg)
+}
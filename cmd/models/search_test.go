@@ -0,0 +1,148 @@
+package models
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseMoveSet(t *testing.T) {
+	testCases := []struct {
+		moveSet  string
+		expected []string
+		err      string
+	}{
+		{"<R,U>", []string{"R", "U"}, ""},
+		{"RUF", []string{"R", "U", "F"}, ""},
+		{"<R, U, M>", []string{"R", "U", "M"}, ""},
+		{"<R,r,U>", []string{"R", "r", "U"}, ""},
+		{"<R,y>", nil, "invalid move set: unknown move y"},
+		{"RUR", nil, "invalid move set: R appears twice"},
+		{"<>", nil, "invalid move set: no moves"},
+	}
+
+	for _, tc := range testCases {
+		faces, err := ParseMoveSet(tc.moveSet)
+		if tc.err != "" {
+			if err == nil || err.Error() != tc.err {
+				t.Errorf("Expected error %q for %s, got %v", tc.err, tc.moveSet, err)
+			}
+			continue
+		}
+
+		if err != nil || !reflect.DeepEqual(faces, tc.expected) {
+			t.Errorf("Expected %v for %s, got %v (%v)", tc.expected, tc.moveSet, faces, err)
+		}
+	}
+}
+
+func TestSearchAlgorithms(t *testing.T) {
+	testCases := []struct {
+		name     string
+		setup    string
+		options  SearchOptions
+		expected string
+	}{
+		{
+			name:     "Sune",
+			setup:    "R U2 R' U' R U' R'",
+			options:  SearchOptions{Faces: []string{"R", "U"}, MaxLength: 7},
+			expected: "R U R' U R U2 R'",
+		},
+		{
+			name:     "Sexy Move With F",
+			setup:    "U R U' R'",
+			options:  SearchOptions{Faces: []string{"R", "U", "F"}, MaxLength: 4, Workers: 2},
+			expected: "R U R' U'",
+		},
+		{
+			name:     "Orientation Only",
+			setup:    "F R U R' U' F'",
+			options:  SearchOptions{Faces: []string{"R", "U", "F"}, MaxLength: 6, Goal: "oll"},
+			expected: "F U R U' R' F'",
+		},
+		{
+			name:     "Slices",
+			setup:    "M2 U M2 U2 M2 U M2",
+			options:  SearchOptions{Faces: []string{"M", "U"}, MaxLength: 7},
+			expected: "M2 U M2 U2 M2 U M2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cube := New()
+			cube.ApplyAlgorithm(tc.setup)
+			start, _ := cube.permutation()
+
+			results, err := cube.SearchAlgorithms(context.Background(), tc.options)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			goal := tc.options.Goal
+			if goal == "" {
+				goal = "solved"
+			}
+
+			found := false
+			seen := make(map[string]bool)
+			for _, alg := range results {
+				if alg.String() == tc.expected {
+					found = true
+				}
+
+				if seen[alg.String()] {
+					t.Errorf("Duplicate result %s", alg)
+				}
+				seen[alg.String()] = true
+
+				if len(alg) > tc.options.MaxLength {
+					t.Errorf("Result %s is longer than %d", alg, tc.options.MaxLength)
+				}
+				if !start.then(alg.permutation()).reoriented().meets(goal) {
+					t.Errorf("Result %s does not reach %s", alg, goal)
+				}
+			}
+
+			if !found {
+				t.Errorf("Expected %s among %v", tc.expected, results)
+			}
+		})
+	}
+}
+
+func TestSearchAlgorithmsErrors(t *testing.T) {
+	cube := New()
+	cube.ApplyAlgorithm("R U")
+
+	if _, err := cube.SearchAlgorithms(context.Background(), SearchOptions{Faces: []string{"R", "U"}}); err == nil {
+		t.Errorf("Expected an error for a missing max length")
+	}
+
+	if _, err := cube.SearchAlgorithms(context.Background(), SearchOptions{Faces: []string{"R", "Q"}, MaxLength: 3}); err == nil {
+		t.Errorf("Expected an error for an unknown move")
+	}
+
+	if _, err := cube.SearchAlgorithms(context.Background(), SearchOptions{Faces: []string{"R", "U"}, MaxLength: 3, Goal: "pll"}); err == nil {
+		t.Errorf("Expected an error for an unknown goal")
+	}
+}
+
+func TestSearchAlgorithmsCancel(t *testing.T) {
+	cube := New()
+	cube.ApplyAlgorithm("R U R' U' R' F R2 U' R' U' R U R' F'")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := cube.SearchAlgorithms(ctx, SearchOptions{Faces: []string{"R", "U", "F", "L", "D", "B"}, MaxLength: 20})
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected the deadline to be exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Search took %v to stop after cancellation", elapsed)
+	}
+}
@@ -0,0 +1,68 @@
+package models
+
+type Simplification struct {
+	Algorithm Algorithm
+	Before    int
+	After     int
+}
+
+// Simplify cancels and merges moves that act on the same layer. Moves about
+// the same axis commute, so "R L R" becomes "R2 L" and "R U U' R'" cancels
+// completely.
+func (a Algorithm) Simplify() Simplification {
+	result := a.normalize()
+	for {
+		before := len(result)
+		result = mergeTurns(result)
+		if len(result) == before {
+			break
+		}
+	}
+
+	return Simplification{
+		Algorithm: result,
+		Before:    len(a),
+		After:     len(result),
+	}
+}
+
+func (a Algorithm) normalize() Algorithm {
+	result := Algorithm{}
+	for _, turn := range a {
+		turn.Amount = ((turn.Amount % 4) + 4) % 4
+		if _, ok := turnDefinitions[turn.Face]; ok && turn.Amount != 0 {
+			result = append(result, turn)
+		}
+	}
+	return result
+}
+
+func mergeTurns(a Algorithm) Algorithm {
+	result := Algorithm{}
+	for _, turn := range a {
+		merged := false
+		for j := len(result) - 1; j >= 0 && sameAxis(result[j], turn); j-- {
+			if result[j].Face != turn.Face {
+				continue
+			}
+
+			amount := (result[j].Amount + turn.Amount) % 4
+			if amount == 0 {
+				result = append(result[:j], result[j+1:]...)
+			} else {
+				result[j].Amount = amount
+			}
+			merged = true
+			break
+		}
+
+		if !merged {
+			result = append(result, turn)
+		}
+	}
+	return result
+}
+
+func sameAxis(a, b Turn) bool {
+	return turnDefinitions[a.Face].axis == turnDefinitions[b.Face].axis
+}
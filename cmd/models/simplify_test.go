@@ -0,0 +1,66 @@
+package models
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestSimplify(t *testing.T) {
+	testCases := []struct {
+		notation string
+		expected string
+		before   int
+		after    int
+	}{
+		{"R U U' R2 F F F", "R' F'", 7, 2},
+		{"R L R", "R2 L", 3, 2},
+		{"R U R' U'", "R U R' U'", 4, 4},
+		{"R U U' R'", "", 4, 0},
+		{"R L U2 U2 R' L'", "", 6, 0},
+		{"F B F' B2 S", "B' S", 5, 2},
+		{"U D U D U D U D", "", 8, 0},
+		{"x R x' M'", "R M'", 4, 2},
+		{"R2 R2 R", "R", 3, 1},
+	}
+
+	for _, tc := range testCases {
+		simplified := mustParse(t, tc.notation).Simplify()
+
+		if simplified.Algorithm.String() != tc.expected {
+			t.Errorf("Expected %q to simplify to %q, got %q", tc.notation, tc.expected, simplified.Algorithm)
+		}
+
+		if simplified.Before != tc.before || simplified.After != tc.after {
+			t.Errorf("Expected move counts %d -> %d for %q, got %d -> %d",
+				tc.before, tc.after, tc.notation, simplified.Before, simplified.After)
+		}
+	}
+}
+
+func TestSimplifyPreservesEffect(t *testing.T) {
+	faces := []string{"U", "D", "F", "B", "L", "R", "M", "E", "S", "r", "x"}
+	random := rand.New(rand.NewSource(1))
+
+	for n := 0; n < 200; n++ {
+		alg := Algorithm{}
+		for i := 0; i < 20; i++ {
+			alg = append(alg, Turn{Face: faces[random.Intn(len(faces))], Amount: 1 + random.Intn(3)})
+		}
+
+		expected := New()
+		expected.Apply(alg)
+
+		simplified := alg.Simplify().Algorithm
+		cube := New()
+		cube.Apply(simplified)
+
+		if !reflect.DeepEqual(cube, expected) {
+			t.Fatalf("Simplifying %s to %s changed the resulting state", alg, simplified)
+		}
+
+		if len(simplified) > len(alg) {
+			t.Errorf("Simplifying %s made it longer: %s", alg, simplified)
+		}
+	}
+}
@@ -0,0 +1,393 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrUnsolvable is returned when the cube cannot be solved with the moves
+// the solver is restricted to.
+var ErrUnsolvable = errors.New("unsolvable in this group")
+
+// SolveOptions restricts the solver to a move set, by default the six outer
+// faces, and to solutions of at most MaxLength turns, 20 by default.
+type SolveOptions struct {
+	Faces     []string
+	MaxLength int
+}
+
+// Solution is a solution within the move set, counted in turns of the set.
+// Rotation brings the cube into the subgroup first when it is held in
+// another orientation. Optimal is false for a full-cube solution from the
+// two-phase solver, which is at most twoPhaseMaxLength turns but may not be
+// the shortest.
+type Solution struct {
+	Rotation  Algorithm `json:"rotation"`
+	Algorithm Algorithm `json:"algorithm"`
+	Moves     int       `json:"moves"`
+	Optimal   bool      `json:"optimal"`
+}
+
+var (
+	defaultSolveFaces     = []string{"U", "D", "F", "B", "L", "R"}
+	defaultSolveMaxLength = 20
+)
+
+// solveMoveSets are the move sets Solve accepts. Each one keeps pruning
+// tables of up to 80MB for good, so only a fixed list is allowed.
+var solveMoveSets = [][]string{
+	defaultSolveFaces,
+	{"R", "U"},
+	{"R", "U", "F"},
+	{"R", "U", "M"},
+	{"R", "r", "U"},
+	{"M", "U"},
+}
+
+// optimalSolveTime is how long Solve looks for an optimal solution of a
+// full-cube state before it settles for a two-phase one.
+const optimalSolveTime = 2 * time.Second
+
+// SolveMoveSets lists the move sets Solve accepts, written like "<R,U>".
+func SolveMoveSets() []string {
+	var result []string
+	for _, faces := range solveMoveSets {
+		result = append(result, "<"+strings.Join(faces, ",")+">")
+	}
+	return result
+}
+
+// IsSolveMoveSet reports whether Solve accepts the move set, in any order.
+func IsSolveMoveSet(faces []string) bool {
+	for _, set := range solveMoveSets {
+		if moveSetKey(set) == moveSetKey(faces) {
+			return true
+		}
+	}
+	return false
+}
+
+// Solve finds a shortest solution of the cube using only the turns of the
+// move set. States outside the subgroup generated by the move set are
+// rejected with ErrUnsolvable before any search is done. A full-cube state
+// with no optimal solution found within optimalSolveTime gets a two-phase
+// solution instead; MaxLength then only applies when it is set.
+func (c *RubiksCube) Solve(ctx context.Context, options SolveOptions) (*Solution, error) {
+	p, err := c.permutation()
+	if err != nil {
+		return nil, err
+	}
+
+	faces := options.Faces
+	if len(faces) == 0 {
+		faces = defaultSolveFaces
+	}
+	if err := checkMoveSet(faces); err != nil {
+		return nil, err
+	}
+	if !IsSolveMoveSet(faces) {
+		return nil, fmt.Errorf("unsupported move set: use one of %s", strings.Join(SolveMoveSets(), ", "))
+	}
+
+	group := generatedSubgroup(faces)
+	for _, r := range rotations {
+		start := p.then(r.perm)
+		if group.contains(start) {
+			return solveFrom(ctx, r, start, faces, options.MaxLength)
+		}
+	}
+	return nil, ErrUnsolvable
+}
+
+// solveFrom solves start, the state after the rotation r, which is in the
+// subgroup of the faces.
+func solveFrom(ctx context.Context, r rotation, start permutation, faces []string, requestedLength int) (*Solution, error) {
+	maxLength := requestedLength
+	if maxLength == 0 {
+		maxLength = defaultSolveMaxLength
+	}
+
+	var tables []*pruningTable
+	for _, facelets := range [][]int{cornerFacelets(), append(edgeFacelets(), centers...)} {
+		t, err := positionTableContext(ctx, faces, facelets)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, t)
+	}
+
+	// The time for an optimal full-cube solution starts once the tables are
+	// there, so that building them does not count against the search
+	searchCtx := ctx
+	full := moveSetKey(faces) == moveSetKey(defaultSolveFaces)
+	if full {
+		var cancel context.CancelFunc
+		searchCtx, cancel = context.WithTimeout(ctx, optimalSolveTime)
+		defer cancel()
+	}
+
+	solved := func(inverse permutation) bool { return inverse == identity() }
+	alg, err := search(searchCtx, start, solved, faces, tables, maxLength)
+	if full && errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		return solveFullCube(ctx, r, start, requestedLength)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Solution{Rotation: r.alg, Algorithm: alg, Moves: len(alg), Optimal: true}, nil
+}
+
+// solveFullCube solves a full-cube state, with its centers solved after the
+// rotation r, with the two-phase solver.
+func solveFullCube(ctx context.Context, r rotation, start permutation, maxLength int) (*Solution, error) {
+	alg, err := solveTwoPhase(ctx, start)
+	if err != nil {
+		return nil, err
+	}
+	if maxLength > 0 && len(alg) > maxLength {
+		return nil, fmt.Errorf("no solution found within %d moves in time, the two-phase solution has %d", maxLength, len(alg))
+	}
+	return &Solution{Rotation: r.alg, Algorithm: alg, Moves: len(alg)}, nil
+}
+
+func cornerFacelets() []int {
+	var result []int
+	for i := 0; i < faceletCount; i++ {
+		if isCorner(i) {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+func edgeFacelets() []int {
+	var result []int
+	for i := 0; i < faceletCount; i++ {
+		if isEdge(i) {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// patternKey holds the part of a state a pruning table looks at.
+type patternKey [32]uint8
+
+// maxPruningTableSize bounds the number of entries of a pruning table. The
+// breadth-first search stops there and unknown patterns are estimated at one
+// more than the last complete depth.
+const maxPruningTableSize = 1 << 19
+
+// pruningTable stores how many turns of a move set it takes to solve the
+// pattern that project reads from the inverse of a state, for every pattern
+// within reach.
+type pruningTable struct {
+	project func(inverse permutation) patternKey
+	depths  map[patternKey]uint8
+	depth   int
+}
+
+// pruningTableEntry is a cached table, ready once done is closed.
+type pruningTableEntry struct {
+	done  chan struct{}
+	table *pruningTable
+}
+
+var (
+	pruningTableCache = make(map[string]*pruningTableEntry)
+	pruningTableMutex sync.Mutex
+)
+
+// positionTable tracks where the given facelets are.
+func positionTable(faces []string, facelets []int) *pruningTable {
+	t, _ := positionTableContext(context.Background(), faces, facelets)
+	return t
+}
+
+// positionTableContext is positionTable that stops waiting for the table
+// when ctx is done.
+func positionTableContext(ctx context.Context, faces []string, facelets []int) (*pruningTable, error) {
+	return cachedPruningTable(ctx, fmt.Sprint("positions", facelets), faces, func(inverse permutation) patternKey {
+		var k patternKey
+		for i, f := range facelets {
+			k[i] = uint8(inverse[f])
+		}
+		return k
+	})
+}
+
+func buildPruningTable(name string, faces []string, project func(permutation) patternKey) *pruningTable {
+	t, _ := cachedPruningTable(context.Background(), name, faces, project)
+	return t
+}
+
+// cachedPruningTable returns the table of the name and move set, building
+// it the first time. The build runs on its own, outside the cache lock, so
+// that a request can stop waiting for it while other tables are served.
+func cachedPruningTable(ctx context.Context, name string, faces []string, project func(permutation) patternKey) (*pruningTable, error) {
+	key := moveSetKey(faces) + " " + name
+
+	pruningTableMutex.Lock()
+	entry, ok := pruningTableCache[key]
+	if !ok {
+		entry = &pruningTableEntry{done: make(chan struct{})}
+		pruningTableCache[key] = entry
+		go func() {
+			entry.table = fillPruningTable(faces, project)
+			close(entry.done)
+		}()
+	}
+	pruningTableMutex.Unlock()
+
+	select {
+	case <-entry.done:
+		return entry.table, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func fillPruningTable(faces []string, project func(permutation) patternKey) *pruningTable {
+	t := &pruningTable{project: project, depths: make(map[patternKey]uint8)}
+	moves := inverseTurns(faces)
+
+	// The search keeps one state for every pattern it has reached, stored a
+	// byte per facelet to keep large levels small.
+	t.depths[project(identity())] = 0
+	level := []compactPermutation{compact(identity())}
+	for len(level) > 0 {
+		var next []compactPermutation
+		for _, c := range level {
+			inverse := c.expand()
+			for _, move := range moves {
+				moved := move.then(inverse)
+				k := project(moved)
+				if _, ok := t.depths[k]; ok {
+					continue
+				}
+				if len(t.depths) == maxPruningTableSize {
+					// The next depth is incomplete, so unknown patterns can
+					// only be estimated at one more than the last full one.
+					return t
+				}
+				t.depths[k] = uint8(t.depth + 1)
+				next = append(next, compact(moved))
+			}
+		}
+		if len(next) > 0 {
+			t.depth++
+		}
+		level = next
+	}
+	return t
+}
+
+func (t *pruningTable) estimate(inverse permutation) int {
+	if d, ok := t.depths[t.project(inverse)]; ok {
+		return int(d)
+	}
+	return t.depth + 1
+}
+
+type compactPermutation [faceletCount]uint8
+
+func compact(p permutation) compactPermutation {
+	var c compactPermutation
+	for i := range p {
+		c[i] = uint8(p[i])
+	}
+	return c
+}
+
+func (c compactPermutation) expand() permutation {
+	var p permutation
+	for i := range c {
+		p[i] = int(c[i])
+	}
+	return p
+}
+
+func inverseTurns(faces []string) []permutation {
+	var result []permutation
+	for _, face := range faces {
+		for amount := 1; amount <= 3; amount++ {
+			result = append(result, turnPermutations[Turn{Face: face, Amount: amount}].inverse())
+		}
+	}
+	return result
+}
+
+// search runs IDA* from the state start until solved holds.
+// It works on the inverse of the state, where a turn m changes inverse into
+// m⁻¹·inverse, so that the pruning tables can be read directly.
+func search(ctx context.Context, start permutation, solved func(inverse permutation) bool, faces []string, tables []*pruningTable, maxLength int) (Algorithm, error) {
+	s := &idaSearch{ctx: ctx, solved: solved, tables: tables}
+	for _, face := range faces {
+		for amount := 1; amount <= 3; amount++ {
+			turn := Turn{Face: face, Amount: amount}
+			s.turns = append(s.turns, turn)
+			s.inverses = append(s.inverses, turnPermutations[turn].inverse())
+		}
+	}
+
+	inverse := start.inverse()
+	for bound := s.estimate(inverse); bound <= maxLength; bound++ {
+		found, err := s.visit(inverse, Algorithm{}, bound)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+	return nil, fmt.Errorf("no solution found within %d moves", maxLength)
+}
+
+type idaSearch struct {
+	ctx      context.Context
+	solved   func(inverse permutation) bool
+	turns    []Turn
+	inverses []permutation
+	tables   []*pruningTable
+	nodes    int
+}
+
+func (s *idaSearch) estimate(inverse permutation) int {
+	result := 0
+	for _, t := range s.tables {
+		if d := t.estimate(inverse); d > result {
+			result = d
+		}
+	}
+	return result
+}
+
+func (s *idaSearch) visit(inverse permutation, path Algorithm, bound int) (Algorithm, error) {
+	s.nodes++
+	if s.nodes%searchCheckInterval == 0 && s.ctx.Err() != nil {
+		return nil, s.ctx.Err()
+	}
+
+	if s.solved(inverse) {
+		return append(Algorithm{}, path...), nil
+	}
+	if len(path)+s.estimate(inverse) > bound {
+		return nil, nil
+	}
+
+	for i, turn := range s.turns {
+		if len(path) > 0 && !canFollow(path[len(path)-1], turn) {
+			continue
+		}
+
+		found, err := s.visit(s.inverses[i].then(inverse), append(path, turn), bound)
+		if err != nil || found != nil {
+			return found, err
+		}
+	}
+	return nil, nil
+}
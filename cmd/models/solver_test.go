@@ -0,0 +1,147 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestSolve(t *testing.T) {
+	testCases := []struct {
+		name           string
+		setup          string
+		faces          []string
+		expectedLength int
+		expectedErr    error
+	}{
+		{
+			name:           "Sune In 2-Gen",
+			setup:          "R U2 R' U' R U' R'",
+			faces:          []string{"R", "U"},
+			expectedLength: 7,
+		},
+		{
+			name:           "Scrambled 2-Gen",
+			setup:          "R U R2 U' R' U2 R U R' U2 R2 U R U' R2",
+			faces:          []string{"R", "U"},
+			expectedLength: -1,
+		},
+		{
+			name:           "Roux LSE",
+			setup:          "M' U2 M U M2 U' M' U2 M",
+			faces:          []string{"M", "U"},
+			expectedLength: -1,
+		},
+		{
+			name:           "Full Move Set",
+			setup:          "F R B' D2 L",
+			expectedLength: 5,
+		},
+		{
+			name:        "Outside The Group",
+			setup:       "F",
+			faces:       []string{"R", "U"},
+			expectedErr: ErrUnsolvable,
+		},
+		{
+			name:           "Rotated Cube",
+			setup:          "R U x",
+			faces:          []string{"R", "U"},
+			expectedLength: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cube := New()
+			cube.ApplyAlgorithm(tc.setup)
+
+			solution, err := cube.Solve(context.Background(), SolveOptions{Faces: tc.faces})
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Errorf("Expected error %v, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if tc.expectedLength >= 0 && solution.Moves != tc.expectedLength {
+				t.Errorf("Expected a %d-move solution, got %s", tc.expectedLength, solution.Algorithm)
+			}
+
+			for _, turn := range solution.Algorithm {
+				if tc.faces != nil && !containsString(tc.faces, turn.Face) {
+					t.Errorf("Solution %s leaves the move set %v", solution.Algorithm, tc.faces)
+				}
+			}
+
+			cube.Apply(solution.Rotation)
+			cube.Apply(solution.Algorithm)
+			if p, _ := cube.permutation(); p != identity() {
+				t.Errorf("Solution %s %s does not solve the cube", solution.Rotation, solution.Algorithm)
+			}
+		})
+	}
+}
+
+func TestSolveNoSolutionWithinMaxLength(t *testing.T) {
+	cube := New()
+	cube.ApplyAlgorithm("R U R' U R U2 R'")
+
+	if _, err := cube.Solve(context.Background(), SolveOptions{Faces: []string{"R", "U"}, MaxLength: 4}); err == nil || err.Error() != "no solution found within 4 moves" {
+		t.Errorf("Expected no solution within 4 moves, got %v", err)
+	}
+}
+
+func TestSolveUnsupportedMoveSet(t *testing.T) {
+	if _, err := New().Solve(context.Background(), SolveOptions{Faces: []string{"R", "U", "L", "D"}}); err == nil {
+		t.Errorf("Expected an error for a move set outside the list")
+	}
+}
+
+func TestSolveFullCubeFallsBackToTwoPhase(t *testing.T) {
+	scramble, err := GenerateScramble(context.Background(), SubsetFull, ScrambleConstraints{}, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// A random state is too deep for the optimal search to finish in time
+	solution, err := scramble.Cube.Solve(context.Background(), SolveOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if solution.Optimal || solution.Moves > twoPhaseMaxLength {
+		t.Errorf("Expected a two-phase solution of at most %d moves, got %+v", twoPhaseMaxLength, solution)
+	}
+
+	cube := *scramble.Cube
+	cube.Apply(solution.Rotation)
+	cube.Apply(solution.Algorithm)
+	if !cube.IsSolved() {
+		t.Errorf("Solution %s does not solve the cube", solution.Algorithm)
+	}
+}
+
+func TestPruningTableWaitIsCancelled(t *testing.T) {
+	release := make(chan struct{})
+	project := func(inverse permutation) patternKey {
+		<-release
+		return patternKey{uint8(inverse[0])}
+	}
+
+	// A request gives up on a table that is still being built
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := cachedPruningTable(ctx, "blocked", []string{"U"}, project); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the wait to be cancelled, got %v", err)
+	}
+
+	// The build goes on and the table is there for the next request
+	close(release)
+	if table, err := cachedPruningTable(context.Background(), "blocked", []string{"U"}, project); err != nil || table == nil {
+		t.Errorf("Expected the table once built, got %v", err)
+	}
+}
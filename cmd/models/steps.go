@@ -0,0 +1,210 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+const (
+	StepCross      = "cross"
+	StepXCross     = "xcross"
+	StepEOLine     = "eoline"
+	StepEOCross    = "eocross"
+	StepFirstBlock = "fb"
+)
+
+// StepSolution is an optimal solution of one step with the cube held one
+// way: Bottom and Front are the colors of the centers that end up on D and
+// F after Rotation.
+type StepSolution struct {
+	Step      string    `json:"step"`
+	Bottom    Color     `json:"bottom"`
+	Front     Color     `json:"front,omitempty"`
+	Rotation  Algorithm `json:"rotation"`
+	Algorithm Algorithm `json:"algorithm"`
+	Moves     int       `json:"moves"`
+}
+
+// stepDefinition describes a step on a cube held with D at the bottom and F
+// in front. The pieces must be solved and, when eoAxis is set, every edge
+// oriented. Each group of tables gets a pruning table. withFront tells
+// whether turning the cube about the y axis changes the step.
+type stepDefinition struct {
+	pieces    []string
+	eoAxis    string
+	faces     []string
+	tables    [][]string
+	withFront bool
+}
+
+var (
+	outerFaces = []string{"U", "D", "F", "B", "L", "R"}
+	crossEdges = []string{"DF", "DR", "DB", "DL"}
+)
+
+var steps = map[string]stepDefinition{
+	StepCross: {
+		pieces: crossEdges,
+		faces:  outerFaces,
+		tables: [][]string{crossEdges},
+	},
+	StepXCross: {
+		pieces:    append([]string{"DFR", "FR"}, crossEdges...),
+		faces:     outerFaces,
+		tables:    [][]string{crossEdges, {"DFR", "FR", "DF", "DR"}, {"DFR", "FR", "DB", "DL"}},
+		withFront: true,
+	},
+	StepEOLine: {
+		pieces:    []string{"DF", "DB"},
+		eoAxis:    "fb",
+		faces:     outerFaces,
+		tables:    [][]string{{"DF", "DB"}},
+		withFront: true,
+	},
+	StepEOCross: {
+		pieces:    crossEdges,
+		eoAxis:    "fb",
+		faces:     outerFaces,
+		tables:    [][]string{crossEdges},
+		withFront: true,
+	},
+	StepFirstBlock: {
+		pieces:    []string{"DL", "FL", "BL", "DLF", "DLB"},
+		faces:     append([]string{"M"}, outerFaces...),
+		tables:    [][]string{{"DL", "FL", "BL"}, {"DL", "FL", "DLF", "DLB"}, {"DL", "BL", "DLF", "DLB"}},
+		withFront: true,
+	},
+}
+
+func pieceFacelets(names []string) []int {
+	var result []int
+	for _, name := range names {
+		result = append(result, pieceOf[mustParseSticker(name)]...)
+	}
+	sort.Ints(result)
+	return result
+}
+
+// SolveStep finds an optimal solution of the step for every distinct way of
+// holding the cube, so that color-neutral options can be compared. The
+// solutions are sorted by move count.
+func (c *RubiksCube) SolveStep(ctx context.Context, step string) ([]StepSolution, error) {
+	definition, ok := steps[step]
+	if !ok {
+		return nil, fmt.Errorf("invalid step: %s", step)
+	}
+
+	p, err := c.permutation()
+	if err != nil {
+		return nil, err
+	}
+
+	// Work from the cube with its centers solved and add the rotation that
+	// gets there to every solution.
+	held := p.heldRotation()
+	p = p.then(held.perm)
+
+	var tables []*pruningTable
+	for _, pieces := range definition.tables {
+		tables = append(tables, positionTable(definition.faces, pieceFacelets(pieces)))
+	}
+
+	var references [faceletCount]bool
+	if definition.eoAxis != "" {
+		references = eoReferences(definition.eoAxis)
+		tables = append(tables, edgeOrientationTable(definition.faces, definition.eoAxis))
+	}
+
+	goal := pieceFacelets(definition.pieces)
+	solved := func(inverse permutation) bool {
+		for _, f := range goal {
+			if inverse[f] != f {
+				return false
+			}
+		}
+		return definition.eoAxis == "" || edgesOrientedOn(inverse, references)
+	}
+
+	// Holding the cube rotated by r turns the state into its conjugate,
+	// so every orientation is solved as the D/F step of another state.
+	var solutions []StepSolution
+	var frames []permutation
+	seen := make(map[string]bool)
+	for _, r := range rotations {
+		solution := StepSolution{
+			Step:     step,
+			Bottom:   solvedColors[r.perm[13]],
+			Rotation: rotationAlgorithm(held.perm.then(r.perm)),
+		}
+		key := string(solution.Bottom)
+		if definition.withFront {
+			solution.Front = solvedColors[r.perm[22]]
+			key += string(solution.Front)
+			// EO on F/B and a line from F to B look the same from behind
+			if definition.eoAxis != "" {
+				key = string(solution.Bottom) + orderedPair(solution.Front, solvedColors[r.perm[31]])
+			}
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		solutions = append(solutions, solution)
+		frames = append(frames, r.perm)
+	}
+
+	workers := runtime.NumCPU()
+	jobs := make(chan int)
+	errs := make([]error, len(solutions))
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				start := frames[i].inverse().then(p).then(frames[i])
+				alg, err := search(ctx, start, solved, definition.faces, tables, stepMaxLength)
+				solutions[i].Algorithm = alg
+				solutions[i].Moves = len(alg)
+				errs[i] = err
+			}
+		}()
+	}
+	for i := range solutions {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(solutions, func(i, j int) bool { return solutions[i].Moves < solutions[j].Moves })
+	return solutions, nil
+}
+
+// stepMaxLength is above the longest optimal solution of every step.
+const stepMaxLength = 20
+
+// rotationAlgorithm returns the shortest rotation with the effect p.
+func rotationAlgorithm(p permutation) Algorithm {
+	for _, r := range rotations {
+		if r.perm == p {
+			return r.alg
+		}
+	}
+	return Algorithm{}
+}
+
+func orderedPair(a, b Color) string {
+	if a > b {
+		a, b = b, a
+	}
+	return string(a) + string(b)
+}
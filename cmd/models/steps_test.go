@@ -0,0 +1,93 @@
+package models
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSolveStep(t *testing.T) {
+	testCases := []struct {
+		name           string
+		setup          string
+		step           string
+		orientations   int
+		expectedBottom Color
+		expectedMoves  int
+	}{
+		{"Cross Untouched By F", "F", StepCross, 6, Blue, 0},
+		{"Cross After Scramble", "R2 D' B' L2 F2 U' R2 B2 U' F2 L2 U' F' R U' B D R' F2 D2", StepCross, 6, "", -1},
+		{"X-Cross Solved Slot", "R U R' U'", StepXCross, 24, Yellow, 0},
+		{"EOLine Broken By F", "F", StepEOLine, 12, Blue, 0},
+		{"EOCross", "F R U", StepEOCross, 12, "", -1},
+		{"First Block", "M U2 R' F", StepFirstBlock, 24, "", -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cube := New()
+			cube.ApplyAlgorithm(tc.setup)
+			p, _ := cube.permutation()
+
+			solutions, err := cube.SolveStep(context.Background(), tc.step)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(solutions) != tc.orientations {
+				t.Errorf("Expected %d orientations, got %d", tc.orientations, len(solutions))
+			}
+
+			definition := steps[tc.step]
+			goal := pieceFacelets(definition.pieces)
+			references := eoReferences("fb")
+			for i, solution := range solutions {
+				if i > 0 && solutions[i-1].Moves > solution.Moves {
+					t.Errorf("Solutions are not sorted by move count")
+				}
+
+				// Rotate, solve, and look at the pieces relative to the
+				// centers as they were after the rotation
+				rotated := p.then(solution.Rotation.permutation())
+				held := rotated.then(solution.Algorithm.permutation())
+				var inverse permutation
+				for _, r := range rotations {
+					if sameCenters(r.perm, rotated) {
+						inverse = r.perm.inverse().then(held).inverse()
+					}
+				}
+				for _, f := range goal {
+					if inverse[f] != f {
+						t.Errorf("%s %s does not solve %s", solution.Rotation, solution.Algorithm, tc.step)
+						break
+					}
+				}
+				if definition.eoAxis != "" && !edgesOrientedOn(inverse, references) {
+					t.Errorf("%s %s leaves edges misoriented", solution.Rotation, solution.Algorithm)
+				}
+			}
+
+			if tc.expectedMoves >= 0 && solutions[0].Moves != tc.expectedMoves {
+				t.Errorf("Expected a %d-move solution, got %s", tc.expectedMoves, solutions[0].Algorithm)
+			}
+
+			if tc.expectedMoves == 0 && solutions[0].Bottom != tc.expectedBottom {
+				t.Errorf("Expected the %s %s to be solved, got %s", tc.expectedBottom, tc.step, solutions[0].Bottom)
+			}
+		})
+	}
+}
+
+func TestSolveStepInvalid(t *testing.T) {
+	if _, err := New().SolveStep(context.Background(), "f2l"); err == nil {
+		t.Errorf("Expected an error for an unknown step")
+	}
+}
+
+func sameCenters(p, q permutation) bool {
+	for _, c := range centers {
+		if p[c] != q[c] {
+			return false
+		}
+	}
+	return true
+}
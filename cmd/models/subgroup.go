@@ -0,0 +1,122 @@
+package models
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// subgroup is the group of states generated by the turns of a move set,
+// kept as a stabilizer chain built with the Schreier-Sims algorithm. Level k
+// holds the elements that fix the facelets 0..k-1; transversal[k][j] is one
+// of them that takes facelet j to k, and generators[k] generate the level.
+type subgroup struct {
+	transversal [faceletCount][faceletCount]*permutation
+	generators  [faceletCount][]permutation
+}
+
+var (
+	subgroupCache = make(map[string]*subgroup)
+	subgroupMutex sync.Mutex
+)
+
+// generatedSubgroup returns the subgroup generated by the faces, built once
+// per move set.
+func generatedSubgroup(faces []string) *subgroup {
+	key := moveSetKey(faces)
+
+	subgroupMutex.Lock()
+	defer subgroupMutex.Unlock()
+
+	if g, ok := subgroupCache[key]; ok {
+		return g
+	}
+
+	g := &subgroup{}
+	for k := range g.transversal {
+		id := identity()
+		g.transversal[k][k] = &id
+	}
+	for _, face := range faces {
+		turn := turnPermutations[Turn{Face: face, Amount: 1}]
+		if !g.contains(turn) {
+			g.extend(0, turn)
+		}
+	}
+
+	subgroupCache[key] = g
+	return g
+}
+
+func moveSetKey(faces []string) string {
+	sorted := append([]string{}, faces...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+// contains reports whether the state p can be reached with the move set.
+func (g *subgroup) contains(p permutation) bool {
+	level, _ := g.sift(p, 0)
+	return level == faceletCount
+}
+
+// sift divides p by transversal elements from level k on. It returns the
+// level where no transversal element fits, with what is left of p, or
+// faceletCount once p has been reduced to the identity.
+func (g *subgroup) sift(p permutation, k int) (int, permutation) {
+	for ; k < faceletCount; k++ {
+		u := g.transversal[k][p[k]]
+		if u == nil {
+			return k, p
+		}
+		p = u.inverse().then(p)
+	}
+	return k, p
+}
+
+// extend adds the generator p, which fixes the facelets 0..k-1, to level k
+// and follows it through the elements already there.
+func (g *subgroup) extend(k int, p permutation) {
+	g.generators[k] = append(g.generators[k], p)
+	for j := range g.transversal[k] {
+		if u := g.transversal[k][j]; u != nil {
+			g.add(k, p.then(*u))
+		}
+	}
+}
+
+// add records the element p of level k. When its orbit point is new it
+// becomes a transversal element and is multiplied by every generator of the
+// level; otherwise the quotient by the existing transversal element fixes
+// facelet k and is added to the next level unless that already contains it.
+func (g *subgroup) add(k int, p permutation) {
+	j := p[k]
+	if u := g.transversal[k][j]; u != nil {
+		quotient := u.inverse().then(p)
+		if level, _ := g.sift(quotient, k+1); level < faceletCount {
+			g.extend(k+1, quotient)
+		}
+		return
+	}
+
+	g.transversal[k][j] = &p
+	for _, generator := range g.generators[k] {
+		g.add(k, generator.then(p))
+	}
+}
+
+// order counts the states of the subgroup.
+func (g *subgroup) order() *big.Int {
+	order := big.NewInt(1)
+	for k := range g.transversal {
+		orbit := int64(0)
+		for _, u := range g.transversal[k] {
+			if u != nil {
+				orbit++
+			}
+		}
+		order.Mul(order, big.NewInt(orbit))
+	}
+	return order
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestSubgroupOrder(t *testing.T) {
+	testCases := []struct {
+		faces    []string
+		expected string
+	}{
+		{[]string{"U"}, "4"},
+		{[]string{"R", "U"}, "73483200"},
+		{[]string{"R", "U", "F"}, "170659735142400"},
+		{[]string{"U", "D", "F", "B", "L", "R"}, "43252003274489856000"},
+	}
+
+	for _, tc := range testCases {
+		if order := generatedSubgroup(tc.faces).order().String(); order != tc.expected {
+			t.Errorf("Expected order %s for %v, got %s", tc.expected, tc.faces, order)
+		}
+	}
+}
+
+func TestSubgroupContains(t *testing.T) {
+	testCases := []struct {
+		faces    []string
+		state    string
+		expected bool
+	}{
+		{[]string{"R", "U"}, "R U R' U R U2 R'", true},
+		{[]string{"R", "U"}, "F", false},
+		{[]string{"R", "U"}, "R U R' U' R' F R2 U' R' U' R U R' F'", false},
+		{[]string{"M", "U"}, "M' U2 M U2", true},
+		{[]string{"M", "U"}, "R", false},
+		{[]string{"R", "U", "F"}, "F R U R' U' F'", true},
+	}
+
+	for _, tc := range testCases {
+		alg, _ := ParseAlgorithm(tc.state)
+		if contains := generatedSubgroup(tc.faces).contains(alg.permutation()); contains != tc.expected {
+			t.Errorf("Expected %s in %v to be %v", tc.state, tc.faces, tc.expected)
+		}
+	}
+}
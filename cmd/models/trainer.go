@@ -0,0 +1,216 @@
+package models
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CaseStats is how well one case of the trainer has been answered so far.
+type CaseStats struct {
+	Case        string  `json:"case"`
+	Attempts    int     `json:"attempts"`
+	Correct     int     `json:"correct"`
+	Accuracy    float64 `json:"accuracy"`
+	AverageTime float64 `json:"averageTime"`
+	totalTime   time.Duration
+}
+
+// TrainerResult grades one answer. GuessCorrect and AlgorithmCorrect are
+// only set for the parts that were answered; the answer is Correct when all
+// of them are. Time is in seconds.
+type TrainerResult struct {
+	Case             string    `json:"case"`
+	Name             string    `json:"name"`
+	Correct          bool      `json:"correct"`
+	GuessCorrect     *bool     `json:"guessCorrect,omitempty"`
+	AlgorithmCorrect *bool     `json:"algorithmCorrect,omitempty"`
+	Time             float64   `json:"time"`
+	Stats            CaseStats `json:"stats"`
+}
+
+// Trainer serves random cases from a subset of a last-layer category and
+// keeps statistics per case. Cases that are missed or answered slowly come
+// up more often.
+type Trainer struct {
+	category *LibraryCategory
+	cases    []*LibraryCase
+	stats    map[string]*CaseStats
+	rand     *rand.Rand
+
+	current *LibraryCase
+	state   permutation
+	served  time.Time
+}
+
+func NewTrainer(rng *rand.Rand) *Trainer {
+	return &Trainer{stats: make(map[string]*CaseStats), rand: rng}
+}
+
+// SetSubset restricts the trainer to the cases of a last-layer category,
+// or to all of them when cases is empty. Statistics are kept.
+func (t *Trainer) SetSubset(category string, cases []string) error {
+	c, err := FindLibraryCategory(category)
+	if err != nil {
+		return err
+	}
+	if c.Goal == "f2l" {
+		return fmt.Errorf("category %s is not a last-layer set", category)
+	}
+
+	var subset []*LibraryCase
+	for i := range c.Cases {
+		if len(cases) == 0 || containsString(cases, c.Cases[i].ID) {
+			subset = append(subset, &c.Cases[i])
+		}
+	}
+	for _, id := range cases {
+		if !containsString(caseIDs(subset), id) {
+			return fmt.Errorf("case %s is not in %s", id, category)
+		}
+	}
+
+	t.category = c
+	t.cases = subset
+	t.current = nil
+	return nil
+}
+
+func caseIDs(cases []*LibraryCase) []string {
+	var ids []string
+	for _, c := range cases {
+		ids = append(ids, c.ID)
+	}
+	return ids
+}
+
+// Category is the category of the subset, empty until one is set.
+func (t *Trainer) Category() string {
+	if t.category == nil {
+		return ""
+	}
+	return t.category.ID
+}
+
+// Next picks a case and returns it as a cube, seen from a random side and
+// with the top layer turned at random.
+func (t *Trainer) Next(now time.Time) (*RubiksCube, error) {
+	if len(t.cases) == 0 {
+		return nil, fmt.Errorf("no cases selected")
+	}
+
+	t.current = t.pick()
+	t.state = aufs[t.rand.Intn(len(aufs))].permutation().
+		then(t.current.Setup.permutation()).
+		then(aufs[t.rand.Intn(len(aufs))].permutation())
+	t.served = now
+
+	return fromPermutation(t.state), nil
+}
+
+// pick draws a case with probability proportional to its weight.
+func (t *Trainer) pick() *LibraryCase {
+	weights := make([]float64, len(t.cases))
+	total := 0.0
+	for i, c := range t.cases {
+		weights[i] = t.weight(c.ID)
+		total += weights[i]
+	}
+
+	r := t.rand.Float64() * total
+	for i, w := range weights {
+		if r < w {
+			return t.cases[i]
+		}
+		r -= w
+	}
+	return t.cases[len(t.cases)-1]
+}
+
+// weight is 1 for a case not seen yet. Every miss adds two and every
+// correct answer divides the weight, and a case answered more slowly than
+// the subset on average is weighted up by how much slower it is.
+func (t *Trainer) weight(id string) float64 {
+	s, ok := t.stats[id]
+	if !ok || s.Attempts == 0 {
+		return 1
+	}
+
+	w := float64(1+2*(s.Attempts-s.Correct)) / float64(1+s.Correct)
+
+	var total time.Duration
+	attempts := 0
+	for _, c := range t.cases {
+		if other, ok := t.stats[c.ID]; ok {
+			total += other.totalTime
+			attempts += other.Attempts
+		}
+	}
+	if total > 0 {
+		average := float64(total) / float64(attempts)
+		if own := float64(s.totalTime) / float64(s.Attempts); own > average {
+			w *= own / average
+		}
+	}
+	return w
+}
+
+// Answer grades a guess of the case, given by id, name or number, and an
+// algorithm, either of which may be left out. The algorithm is right when
+// it brings the cube to the goal of the category, allowing a final AUF.
+func (t *Trainer) Answer(guess string, alg Algorithm, now time.Time) (*TrainerResult, error) {
+	if t.current == nil {
+		return nil, fmt.Errorf("no case to answer, ask for the next one first")
+	}
+	guess = strings.TrimSpace(guess)
+	if guess == "" && len(alg) == 0 {
+		return nil, fmt.Errorf("a guess or an algorithm is required")
+	}
+
+	c := t.current
+	result := &TrainerResult{Case: c.ID, Name: c.Name, Correct: true, Time: now.Sub(t.served).Seconds()}
+	if guess != "" {
+		right := strings.EqualFold(guess, c.ID) || strings.EqualFold(guess, c.Name) ||
+			(c.Number > 0 && guess == strconv.Itoa(c.Number))
+		result.GuessCorrect = &right
+		result.Correct = result.Correct && right
+	}
+	if len(alg) > 0 {
+		right := t.state.then(alg.permutation()).reached(t.category.Goal)
+		result.AlgorithmCorrect = &right
+		result.Correct = result.Correct && right
+	}
+
+	s, ok := t.stats[c.ID]
+	if !ok {
+		s = &CaseStats{Case: c.ID}
+		t.stats[c.ID] = s
+	}
+	s.Attempts++
+	if result.Correct {
+		s.Correct++
+	}
+	s.totalTime += now.Sub(t.served)
+	s.Accuracy = float64(s.Correct) / float64(s.Attempts)
+	s.AverageTime = s.totalTime.Seconds() / float64(s.Attempts)
+
+	result.Stats = *s
+	t.current = nil
+	return result, nil
+}
+
+// Stats lists the statistics of every case answered so far, in library
+// order.
+func (t *Trainer) Stats() []CaseStats {
+	result := []CaseStats{}
+	for _, category := range library {
+		for _, c := range category.Cases {
+			if s, ok := t.stats[c.ID]; ok {
+				result = append(result, *s)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestTrainerSubset(t *testing.T) {
+	testCases := []struct {
+		name     string
+		category string
+		cases    []string
+		expected int
+		wantErr  bool
+	}{
+		{"All PLLs", "pll", nil, 21, false},
+		{"G Perms", "pll", []string{"pll-ga", "pll-gb", "pll-gc", "pll-gd"}, 4, false},
+		{"Unknown Category", "zbll", nil, 0, true},
+		{"Not Last Layer", "f2l", nil, 0, true},
+		{"Case From Another Category", "pll", []string{"oll-27"}, 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			trainer := NewTrainer(rand.New(rand.NewSource(1)))
+			err := trainer.SetSubset(tc.category, tc.cases)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Expected error %v, got %v", tc.wantErr, err)
+			}
+			if len(trainer.cases) != tc.expected {
+				t.Errorf("Expected %d cases, got %d", tc.expected, len(trainer.cases))
+			}
+		})
+	}
+}
+
+func TestTrainerAnswer(t *testing.T) {
+	trainer := NewTrainer(rand.New(rand.NewSource(1)))
+	trainer.SetSubset("pll", []string{"pll-ga", "pll-gb", "pll-gc", "pll-gd"})
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if _, err := trainer.Answer("Ga", nil, start); err == nil {
+		t.Errorf("Expected an error before a case is served")
+	}
+
+	cube, err := trainer.Next(start)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	p, _ := cube.permutation()
+	if p != trainer.state {
+		t.Errorf("Served cube does not show the case")
+	}
+	if p.reached("solved") {
+		t.Errorf("Served cube is already solved")
+	}
+
+	// A right name and an algorithm that solves the case, allowing the
+	// adjustments around it
+	c := trainer.current
+	match, ok := p.match(c.Algorithms[0], "solved")
+	if !ok {
+		t.Fatalf("No adjustment of %s solves the served cube", c.Algorithms[0])
+	}
+	result, err := trainer.Answer(c.Name, match.Sequence, start.Add(3*time.Second))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.Correct || !*result.GuessCorrect || !*result.AlgorithmCorrect {
+		t.Errorf("Expected a correct answer, got %+v", result)
+	}
+	if result.Time != 3 || result.Stats.AverageTime != 3 || result.Stats.Accuracy != 1 {
+		t.Errorf("Unexpected timing or accuracy: %+v", result)
+	}
+
+	if _, err := trainer.Answer(c.Name, nil, start); err == nil {
+		t.Errorf("Expected an error when answering the same case twice")
+	}
+
+	// A wrong guess without an algorithm
+	trainer.Next(start)
+	wrong := "pll-aa"
+	result, _ = trainer.Answer(wrong, nil, start.Add(time.Second))
+	if result.Correct || *result.GuessCorrect || result.AlgorithmCorrect != nil {
+		t.Errorf("Expected only a wrong guess, got %+v", result)
+	}
+
+	if _, err := trainer.Answer("", nil, start); err == nil {
+		t.Errorf("Expected an error without a guess or an algorithm")
+	}
+}
+
+func TestTrainerWeights(t *testing.T) {
+	trainer := NewTrainer(rand.New(rand.NewSource(1)))
+	trainer.SetSubset("pll", []string{"pll-ga", "pll-gb", "pll-gc", "pll-gd"})
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Miss every Ga and get every other case right, all equally fast
+	for i := 0; i < 40; i++ {
+		trainer.Next(start)
+		guess := trainer.current.ID
+		if guess == "pll-ga" {
+			guess = "pll-gb"
+		}
+		trainer.Answer(guess, nil, start.Add(time.Second))
+	}
+
+	if trainer.weight("pll-ga") <= trainer.weight("pll-gb") {
+		t.Errorf("Expected the missed case to weigh more: %v <= %v", trainer.weight("pll-ga"), trainer.weight("pll-gb"))
+	}
+
+	counts := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		counts[trainer.pick().ID]++
+	}
+	for _, id := range []string{"pll-gb", "pll-gc", "pll-gd"} {
+		if counts["pll-ga"] <= counts[id] {
+			t.Errorf("Expected pll-ga to come up more often than %s: %v", id, counts)
+		}
+	}
+
+	stats := trainer.Stats()
+	if len(stats) != 4 || stats[0].Case != "pll-ga" || stats[0].Correct != 0 {
+		t.Errorf("Unexpected statistics: %+v", stats)
+	}
+}
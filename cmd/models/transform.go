@@ -0,0 +1,139 @@
+package models
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+var mirrorPlanes = map[string]int{"M": 0, "E": 1, "S": 2}
+
+func (a Algorithm) Inverse() Algorithm {
+	inverse := make(Algorithm, len(a))
+	for i, turn := range a {
+		inverse[len(a)-1-i] = Turn{Face: turn.Face, Amount: (4 - turn.Amount%4) % 4}
+	}
+	return inverse
+}
+
+// Mirror reflects the algorithm across the M (left-right), E (up-down) or S
+// (front-back) plane, e.g. turning a right-hand F2L insert into its
+// left-hand version.
+func (a Algorithm) Mirror(plane string) (Algorithm, error) {
+	axis, ok := mirrorPlanes[plane]
+	if !ok {
+		return nil, fmt.Errorf("invalid mirror plane: %s", plane)
+	}
+
+	reflection := reflectionPermutation(axis)
+	return a.conjugate(reflection, reflection)
+}
+
+// Rotate rewrites the algorithm as seen after a whole-cube rotation, so that
+// performing the result is the same as performing "rotation alg rotation'".
+func (a Algorithm) Rotate(rotation string) (Algorithm, error) {
+	parsed, err := ParseAlgorithm(rotation)
+	if err != nil || len(parsed) != 1 || !parsed[0].IsRotation() {
+		return nil, fmt.Errorf("invalid rotation: %s", rotation)
+	}
+
+	p := parsed[0].permutation()
+	return a.conjugate(p, p.inverse())
+}
+
+func (a Algorithm) conjugate(before, after permutation) (Algorithm, error) {
+	result := Algorithm{}
+	for _, turn := range a {
+		p := turn.permutation()
+		if p == identity() {
+			continue
+		}
+
+		transformed, ok := turnsByEffect[before.then(p).then(after)]
+		if !ok {
+			return nil, fmt.Errorf("cannot transform move %s", turn)
+		}
+		result = append(result, transformed)
+	}
+	return result, nil
+}
+
+func reflectionPermutation(axis int) permutation {
+	var p permutation
+	for i, f := range facelets {
+		f.pos[axis] = -f.pos[axis]
+		f.normal[axis] = -f.normal[axis]
+		p[faceletIndex[f]] = i
+	}
+	return p
+}
+
+var (
+	outerTurnsOnce     sync.Once
+	outerTurnsByEffect map[permutation]Algorithm
+)
+
+// faceTurns rewrites the algorithm with outer face turns only. A slice,
+// wide or rotation turn becomes the outer turns with the same effect on the
+// pieces, and the rotation left over relabels every turn after it. A
+// rotation remains at the end only if the algorithm moves the centers.
+func (a Algorithm) faceTurns() Algorithm {
+	outerTurnsOnce.Do(buildOuterTurns)
+
+	result := Algorithm{}
+	frame := identity()
+	for _, turn := range a {
+		q := frame.then(turn.permutation()).then(frame.inverse())
+		for _, r := range rotations {
+			if outer, ok := outerTurnsByEffect[q.then(r.perm.inverse())]; ok {
+				result = append(result, outer...)
+				frame = r.perm.then(frame)
+				break
+			}
+		}
+	}
+	return append(result, rotationAlgorithm(frame)...)
+}
+
+// buildOuterTurns lists the effects of no turn, one outer turn and two
+// turns of opposite faces, which is what a slice or wide turn leaves once
+// the rotation is taken out.
+func buildOuterTurns() {
+	outerTurnsByEffect = map[permutation]Algorithm{identity(): {}}
+	for _, first := range canonicalSequences(outerFaces, 2)[1:] {
+		if len(first) == 2 && turnDefinitions[first[0].Face].axis != turnDefinitions[first[1].Face].axis {
+			continue
+		}
+		if _, ok := outerTurnsByEffect[first.permutation()]; !ok {
+			outerTurnsByEffect[first.permutation()] = first
+		}
+	}
+}
+
+// IsInverse reports whether the solution only undoes the scramble. Both are
+// written with outer face turns as the cube is held at the start, so that
+// rotations, wide turns and slices do not hide it, and then simplified,
+// with turns of opposite faces in a fixed order.
+func IsInverse(scramble, solution Algorithm) bool {
+	return reflect.DeepEqual(solution.undoneTurns(), scramble.Inverse().undoneTurns())
+}
+
+func (a Algorithm) undoneTurns() Algorithm {
+	turns := a.faceTurns()
+	for len(turns) > 0 && turns[len(turns)-1].IsRotation() {
+		turns = turns[:len(turns)-1]
+	}
+
+	turns = turns.Simplify().Algorithm
+	for start := 0; start < len(turns); {
+		end := start + 1
+		for end < len(turns) && sameAxis(turns[start], turns[end]) {
+			end++
+		}
+		run := turns[start:end]
+		sort.Slice(run, func(i, j int) bool { return run[i].Face < run[j].Face })
+		start = end
+	}
+	return turns
+}
@@ -0,0 +1,182 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+var transformAlgorithms = []string{
+	"R U R' U'",
+	"R U R' U R U2 R'",
+	"F R U' R' U' R U R' F' R U R' U' R' F R F'",
+	"r U R' U' M2 U R U' R' U' M'",
+	"x R2 D2 R U R' D2 R U' R x'",
+	"Rw' D' Fw2 S E2 b",
+}
+
+func mustParse(t *testing.T, notation string) Algorithm {
+	t.Helper()
+
+	alg, err := ParseAlgorithm(notation)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing %q: %v", notation, err)
+	}
+	return alg
+}
+
+func TestAlgorithmInverse(t *testing.T) {
+	for _, notation := range transformAlgorithms {
+		alg := mustParse(t, notation)
+
+		cube := New()
+		cube.Apply(alg)
+		cube.Apply(alg.Inverse())
+
+		if !reflect.DeepEqual(cube, New()) {
+			t.Errorf("Expected %s followed by its inverse %s to solve the cube", alg, alg.Inverse())
+		}
+	}
+
+	if got := mustParse(t, "R U2 F'").Inverse().String(); got != "F U2 R'" {
+		t.Errorf("Expected inverse F U2 R', got %s", got)
+	}
+}
+
+func TestAlgorithmMirror(t *testing.T) {
+	swaps := map[string]map[Color]Color{
+		"M": {Orange: Red, Red: Orange},
+		"E": {White: Yellow, Yellow: White},
+		"S": {Green: Blue, Blue: Green},
+	}
+
+	for plane, swap := range swaps {
+		for _, notation := range transformAlgorithms {
+			alg := mustParse(t, notation)
+
+			mirrored, err := alg.Mirror(plane)
+			if err != nil {
+				t.Fatalf("Unexpected error mirroring %s across %s: %v", alg, plane, err)
+			}
+
+			original := New()
+			original.Apply(alg)
+
+			reflection := reflectionPermutation(mirrorPlanes[plane])
+			colors := original.stickers()
+			var reflected [faceletCount]Color
+			for i := range colors {
+				color := colors[reflection[i]]
+				if swapped, ok := swap[color]; ok {
+					color = swapped
+				}
+				reflected[i] = color
+			}
+			expected := &RubiksCube{}
+			expected.setStickers(reflected)
+
+			cube := New()
+			cube.Apply(mirrored)
+
+			if !reflect.DeepEqual(cube, expected) {
+				t.Errorf("Mirror of %s across %s (%s) does not produce the mirrored state", alg, plane, mirrored)
+			}
+		}
+	}
+
+	mirrored, _ := mustParse(t, "R U R' U'").Mirror("M")
+	if mirrored.String() != "L' U' L U" {
+		t.Errorf("Expected L' U' L U, got %s", mirrored)
+	}
+
+	if _, err := mustParse(t, "R").Mirror("X"); err == nil {
+		t.Error("Expected error for invalid mirror plane, got nil")
+	}
+}
+
+func TestAlgorithmRotate(t *testing.T) {
+	for _, rotation := range []string{"x", "y", "z", "y'", "x2", "z'"} {
+		for _, notation := range transformAlgorithms {
+			alg := mustParse(t, notation)
+
+			rotated, err := alg.Rotate(rotation)
+			if err != nil {
+				t.Fatalf("Unexpected error rotating %s by %s: %v", alg, rotation, err)
+			}
+
+			expected := New()
+			expected.ApplyAlgorithm(rotation)
+			expected.Apply(alg)
+			expected.Apply(mustParse(t, rotation).Inverse())
+
+			cube := New()
+			cube.Apply(rotated)
+
+			if !reflect.DeepEqual(cube, expected) {
+				t.Errorf("Rotation of %s by %s (%s) does not match the conjugated algorithm", alg, rotation, rotated)
+			}
+		}
+	}
+
+	rotated, _ := mustParse(t, "R U R'").Rotate("y")
+	if rotated.String() != "B U B'" {
+		t.Errorf("Expected B U B', got %s", rotated)
+	}
+
+	for _, rotation := range []string{"R", "x y", ""} {
+		if _, err := mustParse(t, "R").Rotate(rotation); err == nil {
+			t.Errorf("Expected error for rotation %q, got nil", rotation)
+		}
+	}
+}
+
+func TestFaceTurns(t *testing.T) {
+	testCases := []string{"R U R' U'", "M U M'", "r U r' F", "x R y2 U", "M2 U M2 U2 M2 U M2", "S E' f2 b"}
+
+	for _, tc := range testCases {
+		t.Run(tc, func(t *testing.T) {
+			alg := mustParse(t, tc)
+			result := alg.faceTurns()
+
+			if result.permutation() != alg.permutation() {
+				t.Errorf("%s does not have the effect of %s", result, alg)
+			}
+			for i, turn := range result {
+				if !containsString(outerFaces, turn.Face) && !(turn.IsRotation() && i >= len(result)-2) {
+					t.Errorf("Unexpected turn %s in %s", turn, result)
+				}
+			}
+		})
+	}
+}
+
+func TestIsInverse(t *testing.T) {
+	scramble := mustParse(t, "R U R' U' F2")
+
+	testCases := []struct {
+		solution string
+		expected bool
+	}{
+		{"F2 U R U' R'", true},
+		{"F2 U R U' R' y", true},
+		{"y L2 U F U' F'", true},
+		{"y2 B2 U L U' L' x2", true},
+		{"F2 U Lw x U' x' Lw'", true},
+		{"F2 U R U' R D2 U2 L2 R2 D2 U2 L2", false},
+		{"y2 B2 U L U' L D2 U2 R2 L2 D2 U2 R2", false},
+		{"F2 U R U' R' R U R' U'", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.solution, func(t *testing.T) {
+			if got := IsInverse(scramble, mustParse(t, tc.solution)); got != tc.expected {
+				t.Errorf("Expected IsInverse to be %v, got %v", tc.expected, got)
+			}
+		})
+	}
+
+	rotated, _ := scramble.Inverse().Rotate("y'")
+	solution := append(mustParse(t, "y"), rotated...)
+	if !IsInverse(scramble, solution) {
+		t.Errorf("Expected %s to be the inverse of %s", solution, scramble)
+	}
+}
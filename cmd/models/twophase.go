@@ -0,0 +1,473 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// The two-phase solver finds short solutions of any state of the cube in a
+// few milliseconds. Phase 1 brings the state into the subgroup G1 generated
+// by U, D, R2, L2, F2 and B2, where every corner and edge is oriented and
+// the E-slice edges are in the E slice; phase 2 solves it with the turns of
+// G1. Both phases run IDA* on coordinates of the pieces, and the first
+// solution within twoPhaseMaxLength moves, with at most phase2MaxLength of
+// them in phase 2, is returned, so the result depends on the state only.
+// Asking for 21 moves or fewer makes some states take seconds.
+
+const (
+	twoPhaseMaxLength = 23
+	phase2MaxLength   = 12
+
+	twistCount     = 2187  // 3^7 corner twists
+	flipCount      = 2048  // 2^11 edge flips
+	sliceCount     = 495   // places of the four E-slice edges, 12 choose 4
+	cornerCount    = 40320 // 8! corner permutations
+	udEdgeCount    = 40320 // 8! permutations of the U and D layer edges
+	sliceEdgeCount = 24    // 4! permutations of the E-slice edges
+)
+
+// cubies describes a state piece by piece: cp[i] is the corner in place i of
+// allCorners and co[i] its twist, ep and eo the same for the edges of
+// allEdges, whose last four are the E-slice edges.
+type cubies struct {
+	cp, co [8]uint8
+	ep, eo [12]uint8
+}
+
+var (
+	cornerReferences = placeReferences(allCorners)
+	edgeReferences   = placeReferences(allEdges)
+)
+
+// placeReferences lists the U or D sticker of every place, or the F or B
+// one for an E-slice edge. A piece is oriented when its own reference
+// sticker is on the reference sticker of its place.
+func placeReferences(places []string) []int {
+	references := make([]int, len(places))
+	for i, place := range places {
+		references[i] = mustParseSticker(place)
+	}
+	return references
+}
+
+func cubiesOf(p permutation) cubies {
+	var c cubies
+	for i, f := range cornerReferences {
+		c.cp[i], c.co[i] = pieceAt(p[f], cornerReferences)
+	}
+	for i, f := range edgeReferences {
+		c.ep[i], c.eo[i] = pieceAt(p[f], edgeReferences)
+	}
+	return c
+}
+
+// pieceAt finds the piece a sticker belongs to and how far around the piece
+// it is from the piece's reference sticker.
+func pieceAt(sticker int, references []int) (uint8, uint8) {
+	for piece, f := range references {
+		for k, s := range pieceCycle[f] {
+			if s == sticker {
+				return uint8(piece), uint8(k)
+			}
+		}
+	}
+	panic(fmt.Sprintf("sticker %d is not on a piece", sticker))
+}
+
+// then applies the turn m, given as cubies, after c.
+func (c cubies) then(m cubies) cubies {
+	var r cubies
+	for i := range r.cp {
+		r.cp[i] = c.cp[m.cp[i]]
+		r.co[i] = (c.co[m.cp[i]] + m.co[i]) % 3
+	}
+	for i := range r.ep {
+		r.ep[i] = c.ep[m.ep[i]]
+		r.eo[i] = (c.eo[m.ep[i]] + m.eo[i]) % 2
+	}
+	return r
+}
+
+func (c cubies) twist() int {
+	t := 0
+	for i := 0; i < 7; i++ {
+		t = t*3 + int(c.co[i])
+	}
+	return t
+}
+
+func (c *cubies) setTwist(t int) {
+	sum := 0
+	for i := 6; i >= 0; i-- {
+		c.co[i] = uint8(t % 3)
+		sum += t % 3
+		t /= 3
+	}
+	c.co[7] = uint8((3 - sum%3) % 3)
+}
+
+func (c cubies) flip() int {
+	f := 0
+	for i := 0; i < 11; i++ {
+		f = f*2 + int(c.eo[i])
+	}
+	return f
+}
+
+func (c *cubies) setFlip(f int) {
+	sum := 0
+	for i := 10; i >= 0; i-- {
+		c.eo[i] = uint8(f % 2)
+		sum += f % 2
+		f /= 2
+	}
+	c.eo[11] = uint8(sum % 2)
+}
+
+// slice numbers the set of places holding the E-slice edges, with the
+// combinatorial number system; the solved state is sliceCount-1.
+func (c cubies) slice() int {
+	s, k := 0, 1
+	for i, piece := range c.ep {
+		if piece >= 8 {
+			s += binomial(i, k)
+			k++
+		}
+	}
+	return s
+}
+
+func (c *cubies) setSlice(s int) {
+	slice, other := uint8(8), uint8(0)
+	k := 4
+	for i := 11; i >= 0; i-- {
+		if k > 0 && binomial(i, k) <= s {
+			s -= binomial(i, k)
+			k--
+			c.ep[i] = slice
+			slice++
+		} else {
+			c.ep[i] = other
+			other++
+		}
+	}
+}
+
+func binomial(n, k int) int {
+	if k > n {
+		return 0
+	}
+	result := 1
+	for i := 0; i < k; i++ {
+		result = result * (n - i) / (i + 1)
+	}
+	return result
+}
+
+// rankPermutation numbers a permutation of 0..n-1 by its Lehmer code.
+func rankPermutation(values []uint8) int {
+	rank := 0
+	for i := range values {
+		smaller := 0
+		for _, v := range values[i+1:] {
+			if v < values[i] {
+				smaller++
+			}
+		}
+		rank = rank*(len(values)-i) + smaller
+	}
+	return rank
+}
+
+func unrankPermutation(rank int, values []uint8, offset uint8) {
+	n := len(values)
+	digits := make([]int, n)
+	for i := n - 1; i >= 0; i-- {
+		digits[i] = rank % (n - i)
+		rank /= n - i
+	}
+
+	var left []uint8
+	for v := uint8(0); v < uint8(n); v++ {
+		left = append(left, v+offset)
+	}
+	for i, d := range digits {
+		values[i] = left[d]
+		left = append(left[:d], left[d+1:]...)
+	}
+}
+
+func (c cubies) cornerPermutation() int {
+	return rankPermutation(c.cp[:])
+}
+
+func (c cubies) udEdgePermutation() int {
+	return rankPermutation(c.ep[:8])
+}
+
+func (c cubies) sliceEdgePermutation() int {
+	var slice [4]uint8
+	for i := range slice {
+		slice[i] = c.ep[8+i] - 8
+	}
+	return rankPermutation(slice[:])
+}
+
+type twoPhaseTables struct {
+	moves       []Turn
+	moveCubies  []cubies
+	phase2Moves []int
+
+	twistMove     [][]uint16
+	flipMove      [][]uint16
+	sliceMove     [][]uint16
+	cornerMove    [][]uint16
+	udEdgeMove    [][]uint16
+	sliceEdgeMove [][]uint16
+
+	twistSlicePrune  []int8
+	flipSlicePrune   []int8
+	cornerSlicePrune []int8
+	udEdgeSlicePrune []int8
+}
+
+var (
+	twoPhaseOnce sync.Once
+	twoPhase     *twoPhaseTables
+)
+
+// PrepareTwoPhase builds the tables of the two-phase solver, which takes
+// about a second, so that the first scramble does not have to wait for it.
+func PrepareTwoPhase() {
+	twoPhaseOnce.Do(buildTwoPhaseTables)
+}
+
+func buildTwoPhaseTables() {
+	t := &twoPhaseTables{}
+	for _, face := range outerFaces {
+		for amount := 1; amount <= 3; amount++ {
+			turn := Turn{Face: face, Amount: amount}
+			if face == "U" || face == "D" || amount == 2 {
+				t.phase2Moves = append(t.phase2Moves, len(t.moves))
+			}
+			t.moves = append(t.moves, turn)
+			t.moveCubies = append(t.moveCubies, cubiesOf(turnPermutations[turn]))
+		}
+	}
+	all := make([]int, len(t.moves))
+	for i := range all {
+		all[i] = i
+	}
+	solved := cubiesOf(identity())
+
+	t.twistMove = t.moveTable(all, twistCount, (*cubies).setTwist, cubies.twist)
+	t.flipMove = t.moveTable(all, flipCount, (*cubies).setFlip, cubies.flip)
+	t.sliceMove = t.moveTable(all, sliceCount, (*cubies).setSlice, cubies.slice)
+	t.cornerMove = t.moveTable(t.phase2Moves, cornerCount, func(c *cubies, r int) {
+		unrankPermutation(r, c.cp[:], 0)
+	}, cubies.cornerPermutation)
+	t.udEdgeMove = t.moveTable(t.phase2Moves, udEdgeCount, func(c *cubies, r int) {
+		unrankPermutation(r, c.ep[:8], 0)
+	}, cubies.udEdgePermutation)
+	t.sliceEdgeMove = t.moveTable(t.phase2Moves, sliceEdgeCount, func(c *cubies, r int) {
+		unrankPermutation(r, c.ep[8:], 8)
+	}, cubies.sliceEdgePermutation)
+
+	t.twistSlicePrune = pruneTable(t.twistMove, t.sliceMove, solved.twist(), solved.slice(), sliceCount)
+	t.flipSlicePrune = pruneTable(t.flipMove, t.sliceMove, solved.flip(), solved.slice(), sliceCount)
+	t.cornerSlicePrune = pruneTable(t.cornerMove, t.sliceEdgeMove, 0, 0, sliceEdgeCount)
+	t.udEdgeSlicePrune = pruneTable(t.udEdgeMove, t.sliceEdgeMove, 0, 0, sliceEdgeCount)
+
+	twoPhase = t
+}
+
+// moveTable records, for every value of a coordinate and every move, the
+// value after the move. The moves are indices into t.moves and the table
+// columns follow their order.
+func (t *twoPhaseTables) moveTable(moves []int, count int, set func(*cubies, int), get func(cubies) int) [][]uint16 {
+	table := make([][]uint16, count)
+	for value := range table {
+		c := cubiesOf(identity())
+		set(&c, value)
+		table[value] = make([]uint16, len(moves))
+		for j, m := range moves {
+			table[value][j] = uint16(get(c.then(t.moveCubies[m])))
+		}
+	}
+	return table
+}
+
+// pruneTable holds the distance to the goal of every pair of values of two
+// coordinates, by breadth-first search from the goal.
+func pruneTable(first, second [][]uint16, goalFirst, goalSecond, secondCount int) []int8 {
+	table := make([]int8, len(first)*secondCount)
+	for i := range table {
+		table[i] = -1
+	}
+
+	start := goalFirst*secondCount + goalSecond
+	table[start] = 0
+	frontier := []int32{int32(start)}
+	for depth := int8(1); len(frontier) > 0; depth++ {
+		var next []int32
+		for _, index := range frontier {
+			a, b := int(index)/secondCount, int(index)%secondCount
+			for j := range first[a] {
+				n := int(first[a][j])*secondCount + int(second[b][j])
+				if table[n] < 0 {
+					table[n] = depth
+					next = append(next, int32(n))
+				}
+			}
+		}
+		frontier = next
+	}
+	return table
+}
+
+type twoPhaseSearch struct {
+	ctx      context.Context
+	t        *twoPhaseTables
+	start    cubies
+	path     []int
+	maxTotal int
+	nodes    int
+	solution Algorithm
+}
+
+// solveTwoPhase returns a solution of p of at most twoPhaseMaxLength outer
+// turns. The centers of p must be solved.
+func solveTwoPhase(ctx context.Context, p permutation) (Algorithm, error) {
+	PrepareTwoPhase()
+
+	s := &twoPhaseSearch{ctx: ctx, t: twoPhase, start: cubiesOf(p), maxTotal: twoPhaseMaxLength}
+	twist, flip, slice := s.start.twist(), s.start.flip(), s.start.slice()
+	for depth := 0; depth <= s.maxTotal; depth++ {
+		found, err := s.phase1(twist, flip, slice, depth)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			return s.solution, nil
+		}
+	}
+	return nil, fmt.Errorf("no solution found within %d moves", s.maxTotal)
+}
+
+func (s *twoPhaseSearch) checkContext() error {
+	s.nodes++
+	if s.nodes%searchCheckInterval == 0 {
+		return s.ctx.Err()
+	}
+	return nil
+}
+
+func (s *twoPhaseSearch) phase1(twist, flip, slice, depth int) (bool, error) {
+	if err := s.checkContext(); err != nil {
+		return false, err
+	}
+
+	if depth == 0 {
+		// A phase 1 that ends with a G1 turn would have reached G1 sooner
+		if twist != 0 || flip != 0 || slice != sliceCount-1 {
+			return false, nil
+		}
+		if len(s.path) > 0 && s.isPhase2Move(s.path[len(s.path)-1]) {
+			return false, nil
+		}
+		return s.startPhase2()
+	}
+
+	estimate := s.t.twistSlicePrune[twist*sliceCount+slice]
+	if e := s.t.flipSlicePrune[flip*sliceCount+slice]; e > estimate {
+		estimate = e
+	}
+	if int(estimate) > depth {
+		return false, nil
+	}
+
+	for m := range s.t.moves {
+		if !s.canFollow(m) {
+			continue
+		}
+		s.path = append(s.path, m)
+		found, err := s.phase1(int(s.t.twistMove[twist][m]), int(s.t.flipMove[flip][m]), int(s.t.sliceMove[slice][m]), depth-1)
+		s.path = s.path[:len(s.path)-1]
+		if found || err != nil {
+			return found, err
+		}
+	}
+	return false, nil
+}
+
+func (s *twoPhaseSearch) startPhase2() (bool, error) {
+	c := s.start
+	for _, m := range s.path {
+		c = c.then(s.t.moveCubies[m])
+	}
+	corner, udEdge, sliceEdge := c.cornerPermutation(), c.udEdgePermutation(), c.sliceEdgePermutation()
+
+	limit := s.maxTotal - len(s.path)
+	if limit > phase2MaxLength {
+		limit = phase2MaxLength
+	}
+	for depth := s.phase2Estimate(corner, udEdge, sliceEdge); depth <= limit; depth++ {
+		found, err := s.phase2(corner, udEdge, sliceEdge, depth)
+		if found || err != nil {
+			return found, err
+		}
+	}
+	return false, nil
+}
+
+func (s *twoPhaseSearch) phase2(corner, udEdge, sliceEdge, depth int) (bool, error) {
+	if err := s.checkContext(); err != nil {
+		return false, err
+	}
+
+	if depth == 0 {
+		if corner != 0 || udEdge != 0 || sliceEdge != 0 {
+			return false, nil
+		}
+		s.solution = Algorithm{}
+		for _, m := range s.path {
+			s.solution = append(s.solution, s.t.moves[m])
+		}
+		return true, nil
+	}
+
+	if s.phase2Estimate(corner, udEdge, sliceEdge) > depth {
+		return false, nil
+	}
+
+	for j, m := range s.t.phase2Moves {
+		if !s.canFollow(m) {
+			continue
+		}
+		s.path = append(s.path, m)
+		found, err := s.phase2(int(s.t.cornerMove[corner][j]), int(s.t.udEdgeMove[udEdge][j]), int(s.t.sliceEdgeMove[sliceEdge][j]), depth-1)
+		s.path = s.path[:len(s.path)-1]
+		if found || err != nil {
+			return found, err
+		}
+	}
+	return false, nil
+}
+
+// phase2Estimate is a lower bound of the phase 2 turns left.
+func (s *twoPhaseSearch) phase2Estimate(corner, udEdge, sliceEdge int) int {
+	estimate := s.t.cornerSlicePrune[corner*sliceEdgeCount+sliceEdge]
+	if e := s.t.udEdgeSlicePrune[udEdge*sliceEdgeCount+sliceEdge]; e > estimate {
+		estimate = e
+	}
+	return int(estimate)
+}
+
+func (s *twoPhaseSearch) canFollow(m int) bool {
+	return len(s.path) == 0 || canFollow(s.t.moves[s.path[len(s.path)-1]], s.t.moves[m])
+}
+
+func (s *twoPhaseSearch) isPhase2Move(m int) bool {
+	return s.t.moves[m].Face == "U" || s.t.moves[m].Face == "D" || s.t.moves[m].Amount == 2
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestCubiesFollowTurns(t *testing.T) {
+	// Applying turns piece by piece agrees with applying them to stickers
+	for _, notation := range []string{"R U R' U'", "F2 B L' D2 R F'", "U D' F B' L R'"} {
+		alg := mustParseAlgorithm(notation)
+		c := cubiesOf(identity())
+		for _, turn := range alg {
+			c = c.then(cubiesOf(turn.permutation()))
+		}
+		if c != cubiesOf(identity().then(alg.permutation())) {
+			t.Errorf("Cubies of %s do not match its stickers", notation)
+		}
+	}
+}
+
+func TestCoordinatesRoundTrip(t *testing.T) {
+	for _, value := range []int{0, 1, 494, 1000, 2186} {
+		c := cubiesOf(identity())
+		c.setTwist(value)
+		if c.twist() != value {
+			t.Errorf("Expected twist %d, got %d", value, c.twist())
+		}
+	}
+	for value := 0; value < sliceCount; value++ {
+		c := cubiesOf(identity())
+		c.setSlice(value)
+		if c.slice() != value {
+			t.Fatalf("Expected slice %d, got %d", value, c.slice())
+		}
+	}
+	for _, value := range []int{0, 1, 5039, 40319} {
+		c := cubiesOf(identity())
+		unrankPermutation(value, c.cp[:], 0)
+		if c.cornerPermutation() != value {
+			t.Errorf("Expected corner permutation %d, got %d", value, c.cornerPermutation())
+		}
+	}
+}
+
+func TestSolveTwoPhase(t *testing.T) {
+	PrepareTwoPhase()
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		p := scrambleSubsets[SubsetFull].random(rng)
+
+		solution, err := solveTwoPhase(context.Background(), p)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(solution) > twoPhaseMaxLength {
+			t.Errorf("Expected at most %d moves, got %d", twoPhaseMaxLength, len(solution))
+		}
+		if p.then(solution.permutation()) != identity() {
+			t.Errorf("%s does not solve the state", solution)
+		}
+	}
+
+	if solution, _ := solveTwoPhase(context.Background(), identity()); len(solution) != 0 {
+		t.Errorf("Expected no moves for a solved cube, got %s", solution)
+	}
+}
+
+func TestSolveTwoPhaseCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p := scrambleSubsets[SubsetFull].random(rand.New(rand.NewSource(1)))
+	if _, err := solveTwoPhase(ctx, p); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the search to stop with the context, got %v", err)
+	}
+}
+
+func BenchmarkSolveTwoPhase(b *testing.B) {
+	PrepareTwoPhase()
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < b.N; i++ {
+		if _, err := solveTwoPhase(context.Background(), scrambleSubsets[SubsetFull].random(rng)); err != nil {
+			b.Fatalf("Unexpected error: %v", err)
+		}
+	}
+}
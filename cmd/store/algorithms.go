@@ -0,0 +1,221 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrNotFound = errors.New("algorithm not found")
+
+// Algorithm is a user-defined algorithm. Cases lists the library cases it
+// solves and is filled in by the store.
+type Algorithm struct {
+	ID        int              `json:"id"`
+	Name      string           `json:"name"`
+	Algorithm models.Algorithm `json:"algorithm"`
+	Tags      []string         `json:"tags"`
+	Notes     string           `json:"notes"`
+	Cases     []string         `json:"cases"`
+	CreatedAt time.Time        `json:"createdAt"`
+	UpdatedAt time.Time        `json:"updatedAt"`
+}
+
+// Filter narrows a listing down to algorithms with the given tag and solving
+// the given library case. Empty fields match everything.
+type Filter struct {
+	Tag  string
+	Case string
+}
+
+// AlgorithmStore keeps user-defined algorithms in memory and writes them to
+// a JSON file in its data directory after every change.
+type AlgorithmStore struct {
+	path       string
+	mutex      sync.RWMutex
+	algorithms []Algorithm
+	nextID     int
+}
+
+const algorithmsFile = "algorithms.json"
+
+type algorithmsData struct {
+	NextID     int         `json:"nextId"`
+	Algorithms []Algorithm `json:"algorithms"`
+}
+
+func NewAlgorithmStore(dir string) (*AlgorithmStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	s := &AlgorithmStore{
+		path:   filepath.Join(dir, algorithmsFile),
+		nextID: 1,
+	}
+
+	content, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var data algorithmsData
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, err
+	}
+	s.algorithms = data.Algorithms
+	if data.NextID > s.nextID {
+		s.nextID = data.NextID
+	}
+	return s, nil
+}
+
+func (s *AlgorithmStore) List(filter Filter) []Algorithm {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	result := []Algorithm{}
+	for _, a := range s.algorithms {
+		if filter.Tag != "" && !containsFold(a.Tags, filter.Tag) {
+			continue
+		}
+		if filter.Case != "" && !containsFold(a.Cases, filter.Case) {
+			continue
+		}
+		result = append(result, a)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+func (s *AlgorithmStore) Get(id int) (Algorithm, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	i := s.index(id)
+	if i < 0 {
+		return Algorithm{}, ErrNotFound
+	}
+	return s.algorithms[i], nil
+}
+
+func (s *AlgorithmStore) Create(a Algorithm) (Algorithm, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	a.ID = s.nextID
+	a.CreatedAt = time.Now().UTC()
+	a.UpdatedAt = a.CreatedAt
+	prepare(&a)
+
+	s.algorithms = append(s.algorithms, a)
+	s.nextID++
+	if err := s.save(); err != nil {
+		s.algorithms = s.algorithms[:len(s.algorithms)-1]
+		s.nextID--
+		return Algorithm{}, err
+	}
+	return a, nil
+}
+
+func (s *AlgorithmStore) Update(id int, a Algorithm) (Algorithm, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	i := s.index(id)
+	if i < 0 {
+		return Algorithm{}, ErrNotFound
+	}
+
+	previous := s.algorithms[i]
+	a.ID = id
+	a.CreatedAt = previous.CreatedAt
+	a.UpdatedAt = time.Now().UTC()
+	prepare(&a)
+
+	s.algorithms[i] = a
+	if err := s.save(); err != nil {
+		s.algorithms[i] = previous
+		return Algorithm{}, err
+	}
+	return a, nil
+}
+
+func (s *AlgorithmStore) Delete(id int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	i := s.index(id)
+	if i < 0 {
+		return ErrNotFound
+	}
+
+	previous := s.algorithms
+	s.algorithms = append(append([]Algorithm{}, s.algorithms[:i]...), s.algorithms[i+1:]...)
+	if err := s.save(); err != nil {
+		s.algorithms = previous
+		return err
+	}
+	return nil
+}
+
+func (s *AlgorithmStore) index(id int) int {
+	for i, a := range s.algorithms {
+		if a.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// prepare records the library cases the algorithm solves and tidies up its
+// tags.
+func prepare(a *Algorithm) {
+	a.Cases = models.SolvedCases(a.Algorithm)
+	if a.Cases == nil {
+		a.Cases = []string{}
+	}
+
+	tags := []string{}
+	for _, tag := range a.Tags {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !containsFold(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	a.Tags = tags
+}
+
+// save writes the store to a temporary file first so that a failed write
+// never leaves a truncated file behind.
+func (s *AlgorithmStore) save() error {
+	content, err := json.MarshalIndent(algorithmsData{NextID: s.nextID, Algorithms: s.algorithms}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
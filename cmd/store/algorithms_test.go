@@ -0,0 +1,103 @@
+package store
+
+import (
+	"errors"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"reflect"
+	"testing"
+)
+
+func mustParse(t *testing.T, notation string) models.Algorithm {
+	alg, err := models.ParseAlgorithm(notation)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return alg
+}
+
+func TestAlgorithmStoreCRUD(t *testing.T) {
+	s, err := NewAlgorithmStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	sune, err := s.Create(Algorithm{
+		Name:      "Sune",
+		Algorithm: mustParse(t, "R U R' U R U2 R'"),
+		Tags:      []string{"oll", " favourite ", "OLL"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if sune.ID != 1 || !reflect.DeepEqual(sune.Tags, []string{"oll", "favourite"}) {
+		t.Errorf("Unexpected stored algorithm: %+v", sune)
+	}
+	if !reflect.DeepEqual(sune.Cases, []string{"oll-27", "ocll-sune"}) {
+		t.Errorf("Expected Sune to solve OLL 27, got %v", sune.Cases)
+	}
+
+	tperm, _ := s.Create(Algorithm{
+		Name:      "T perm",
+		Algorithm: mustParse(t, "R U R' U' R' F R2 U' R' U' R U R' F'"),
+		Tags:      []string{"pll"},
+	})
+
+	updated, err := s.Update(sune.ID, Algorithm{
+		Name:      "Sune",
+		Algorithm: mustParse(t, "R U R' U R U2 R'"),
+		Tags:      []string{"oll"},
+		Notes:     "Use a regrip before U2",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if updated.Notes != "Use a regrip before U2" || !updated.CreatedAt.Equal(sune.CreatedAt) {
+		t.Errorf("Unexpected updated algorithm: %+v", updated)
+	}
+
+	if result := s.List(Filter{Tag: "PLL"}); len(result) != 1 || result[0].ID != tperm.ID {
+		t.Errorf("Expected to find the T perm by tag, got %+v", result)
+	}
+	if result := s.List(Filter{Case: "oll-27"}); len(result) != 1 || result[0].ID != sune.ID {
+		t.Errorf("Expected to find Sune by case, got %+v", result)
+	}
+
+	if err := s.Delete(tperm.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := s.Get(tperm.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+	if _, err := s.Update(tperm.ID, updated); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound on update, got %v", err)
+	}
+	if err := s.Delete(tperm.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound on delete, got %v", err)
+	}
+}
+
+func TestAlgorithmStorePersists(t *testing.T) {
+	dir := t.TempDir()
+
+	s, _ := NewAlgorithmStore(dir)
+	first, _ := s.Create(Algorithm{Name: "Sexy move", Algorithm: mustParse(t, "R U R' U'")})
+	s.Create(Algorithm{Name: "Sledgehammer", Algorithm: mustParse(t, "R' F R F'")})
+	s.Delete(first.ID)
+
+	reopened, err := NewAlgorithmStore(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	algorithms := reopened.List(Filter{})
+	if len(algorithms) != 1 || algorithms[0].Name != "Sledgehammer" || algorithms[0].Algorithm.String() != "R' F R F'" {
+		t.Fatalf("Expected the remaining algorithm to be reloaded, got %+v", algorithms)
+	}
+
+	// IDs are not reused after a restart
+	third, _ := reopened.Create(Algorithm{Name: "Sune", Algorithm: mustParse(t, "R U R' U R U2 R'")})
+	if third.ID != 3 {
+		t.Errorf("Expected id 3, got %d", third.ID)
+	}
+}
@@ -0,0 +1,121 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DailyResult is a verified solution of the scramble of a day. Moves is its
+// length in the half-turn metric.
+type DailyResult struct {
+	Date        string           `json:"date"`
+	Name        string           `json:"name"`
+	Solution    models.Algorithm `json:"solution"`
+	Moves       int              `json:"moves"`
+	SubmittedAt time.Time        `json:"submittedAt"`
+}
+
+// DailyStore keeps the leaderboard of every day and writes it to a JSON file
+// in its data directory after every change. Only the best result of each
+// player is kept for a day.
+type DailyStore struct {
+	path    string
+	mutex   sync.RWMutex
+	results map[string][]DailyResult
+}
+
+const dailyFile = "daily.json"
+
+func NewDailyStore(dir string) (*DailyStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	s := &DailyStore{
+		path:    filepath.Join(dir, dailyFile),
+		results: make(map[string][]DailyResult),
+	}
+
+	content, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, &s.results); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Leaderboard lists the results of a day, fewest moves first and earlier
+// submissions first among equals.
+func (s *DailyStore) Leaderboard(date string) []DailyResult {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return append([]DailyResult{}, s.results[date]...)
+}
+
+// Submit records a result and returns the rank, starting at 1, of the
+// player's best result of the day, which is the earlier one when the new
+// result is not shorter.
+func (s *DailyStore) Submit(result DailyResult) (DailyResult, int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	result.Name = strings.TrimSpace(result.Name)
+	result.SubmittedAt = time.Now().UTC()
+
+	previous := s.results[result.Date]
+	board := []DailyResult{}
+	for _, r := range previous {
+		if !strings.EqualFold(r.Name, result.Name) {
+			board = append(board, r)
+		} else if r.Moves <= result.Moves {
+			result = r
+		}
+	}
+	board = append(board, result)
+	sort.SliceStable(board, func(i, j int) bool {
+		if board[i].Moves != board[j].Moves {
+			return board[i].Moves < board[j].Moves
+		}
+		return board[i].SubmittedAt.Before(board[j].SubmittedAt)
+	})
+
+	s.results[result.Date] = board
+	if err := s.save(); err != nil {
+		s.results[result.Date] = previous
+		return DailyResult{}, 0, err
+	}
+
+	for i, r := range board {
+		if r.Name == result.Name {
+			return result, i + 1, nil
+		}
+	}
+	return result, len(board), nil
+}
+
+func (s *DailyStore) save() error {
+	content, err := json.MarshalIndent(s.results, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
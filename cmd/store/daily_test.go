@@ -0,0 +1,55 @@
+package store
+
+import (
+	"testing"
+)
+
+func TestDailyStoreLeaderboard(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewDailyStore(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	submit := func(name string, moves int) (DailyResult, int) {
+		result, rank, err := s.Submit(DailyResult{Date: "2026-10-18", Name: name, Moves: moves})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return result, rank
+	}
+
+	if _, rank := submit("Ana", 50); rank != 1 {
+		t.Errorf("Expected rank 1, got %d", rank)
+	}
+	if _, rank := submit("Boris", 40); rank != 1 {
+		t.Errorf("Expected rank 1, got %d", rank)
+	}
+	if _, rank := submit("Chen", 40); rank != 2 {
+		t.Errorf("Expected rank 2 behind the earlier result, got %d", rank)
+	}
+
+	// A longer solution does not replace a player's best
+	if result, rank := submit("boris", 60); result.Moves != 40 || rank != 1 {
+		t.Errorf("Expected the best result to stay, got %+v at rank %d", result, rank)
+	}
+	if result, rank := submit("Ana", 30); result.Moves != 30 || rank != 1 {
+		t.Errorf("Expected the improved result at rank 1, got %+v at rank %d", result, rank)
+	}
+
+	reopened, err := NewDailyStore(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var names []string
+	for _, r := range reopened.Leaderboard("2026-10-18") {
+		names = append(names, r.Name)
+	}
+	if len(names) != 3 || names[0] != "Ana" || names[1] != "Boris" || names[2] != "Chen" {
+		t.Errorf("Unexpected leaderboard: %v", names)
+	}
+	if board := reopened.Leaderboard("2026-10-17"); len(board) != 0 {
+		t.Errorf("Expected an empty leaderboard for another day, got %+v", board)
+	}
+}
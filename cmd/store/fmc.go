@@ -0,0 +1,95 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FMCResult is a scored fewest-moves attempt, DNFs included.
+type FMCResult struct {
+	Attempt  string           `json:"attempt"`
+	Name     string           `json:"name"`
+	Scramble models.Algorithm `json:"scramble"`
+	Rules    models.FMCRules  `json:"rules"`
+	models.FMCScore
+	SubmittedAt time.Time `json:"submittedAt"`
+}
+
+// FMCStore keeps fewest-moves results in submission order and writes them to
+// a JSON file in its data directory after every change.
+type FMCStore struct {
+	path    string
+	mutex   sync.RWMutex
+	results []FMCResult
+}
+
+const fmcFile = "fmc.json"
+
+func NewFMCStore(dir string) (*FMCStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	s := &FMCStore{path: filepath.Join(dir, fmcFile)}
+
+	content, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, &s.results); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// List returns the results of a player, or of everyone when name is empty.
+func (s *FMCStore) List(name string) []FMCResult {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	result := []FMCResult{}
+	for _, r := range s.results {
+		if name == "" || strings.EqualFold(r.Name, name) {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+func (s *FMCStore) Add(result FMCResult) (FMCResult, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	result.Name = strings.TrimSpace(result.Name)
+	result.SubmittedAt = time.Now().UTC()
+
+	s.results = append(s.results, result)
+	if err := s.save(); err != nil {
+		s.results = s.results[:len(s.results)-1]
+		return FMCResult{}, err
+	}
+	return result, nil
+}
+
+func (s *FMCStore) save() error {
+	content, err := json.MarshalIndent(s.results, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
@@ -0,0 +1,31 @@
+package store
+
+import (
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"testing"
+)
+
+func TestFMCStorePersists(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewFMCStore(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	s.Add(FMCResult{Attempt: "a", Name: "Ana", FMCScore: models.FMCScore{Solution: "R U", Moves: 2}})
+	s.Add(FMCResult{Attempt: "b", Name: "Boris", FMCScore: models.FMCScore{DNF: true, Reason: "solution does not solve the cube"}})
+	s.Add(FMCResult{Attempt: "c", Name: " ana ", FMCScore: models.FMCScore{Solution: "F", Moves: 1}})
+
+	reopened, err := NewFMCStore(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if results := reopened.List(""); len(results) != 3 || !results[1].DNF || results[1].Reason == "" {
+		t.Fatalf("Expected all three results to be reloaded, got %+v", results)
+	}
+	results := reopened.List("ANA")
+	if len(results) != 2 || results[0].Attempt != "a" || results[1].Attempt != "c" || results[1].Moves != 1 {
+		t.Errorf("Expected both of Ana's results in order, got %+v", results)
+	}
+}
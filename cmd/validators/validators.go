@@ -2,7 +2,10 @@ package validators
 
 import (
 	"fmt"
+	"github.com/DamyanDimitrov101/rubiks-cube-simulator/models"
+	"reflect"
 	"regexp"
+	"strings"
 )
 
 func ValidateFace(face string) error {
@@ -39,3 +42,341 @@ func ValidateNotation(notation string) error {
 
 	return nil
 }
+
+func ValidateAlgorithm(algorithm string) error {
+	if strings.TrimSpace(algorithm) == "" {
+		return fmt.Errorf("algorithm cannot be empty")
+	}
+	if len(algorithm) > 1000 {
+		return fmt.Errorf("algorithm must be at most 1000 characters")
+	}
+
+	if _, err := models.ParseAlgorithm(algorithm); err != nil {
+		return fmt.Errorf("invalid algorithm: %v", err)
+	}
+
+	return nil
+}
+
+func ValidateTransform(transform string) error {
+	if transform == "" {
+		return fmt.Errorf("transform cannot be empty")
+	}
+
+	validTransforms := map[string]bool{
+		"inverse": true,
+		"mirror":  true,
+		"rotate":  true,
+	}
+
+	if !validTransforms[transform] {
+		return fmt.Errorf("invalid transform: %s. Valid transforms are: inverse, mirror, rotate", transform)
+	}
+
+	return nil
+}
+
+func ValidatePlane(plane string) error {
+	if plane == "" {
+		return fmt.Errorf("plane cannot be empty")
+	}
+
+	if plane != "M" && plane != "E" && plane != "S" {
+		return fmt.Errorf("invalid plane: %s. Valid planes are: M, E, S", plane)
+	}
+
+	return nil
+}
+
+func ValidateRotation(rotation string) error {
+	if rotation == "" {
+		return fmt.Errorf("rotation cannot be empty")
+	}
+
+	validPattern := regexp.MustCompile(`^[xyz]('|2)?$`)
+
+	if !validPattern.MatchString(rotation) {
+		return fmt.Errorf("invalid rotation: %s. Valid examples: x, y, z, x', y', z', x2, y2, z2", rotation)
+	}
+
+	return nil
+}
+
+func ValidateThreeCycle(stickers []string) error {
+	if len(stickers) != 3 {
+		return fmt.Errorf("cycle must name exactly 3 stickers, got %d", len(stickers))
+	}
+
+	if _, err := models.ThreeCycle([3]string{stickers[0], stickers[1], stickers[2]}); err != nil {
+		return fmt.Errorf("invalid cycle: %v", err)
+	}
+
+	return nil
+}
+
+func ValidateLimit(limit, max int) error {
+	if limit < 0 || limit > max {
+		return fmt.Errorf("limit must be between 0 and %d", max)
+	}
+
+	return nil
+}
+
+func ValidateCornerBuffer(buffer string) error {
+	if buffer == "" {
+		return nil
+	}
+
+	if _, err := models.ParseSticker(buffer); err != nil || len(buffer) != 3 {
+		return fmt.Errorf("invalid corner buffer: %s", buffer)
+	}
+
+	return nil
+}
+
+func ValidateEdgeBuffer(buffer string) error {
+	if buffer == "" {
+		return nil
+	}
+
+	if _, err := models.ParseSticker(buffer); err != nil || len(buffer) != 2 {
+		return fmt.Errorf("invalid edge buffer: %s", buffer)
+	}
+
+	return nil
+}
+
+func ValidateLettering(lettering map[string]string) error {
+	for sticker, letter := range lettering {
+		if _, err := models.ParseSticker(sticker); err != nil {
+			return fmt.Errorf("invalid lettering: %v", err)
+		}
+
+		if strings.TrimSpace(letter) == "" {
+			return fmt.Errorf("invalid lettering: letter for %s cannot be empty", sticker)
+		}
+	}
+
+	return nil
+}
+
+func ValidateExecutionMethod(method string) error {
+	if method != "op" && method != "m2" {
+		return fmt.Errorf("invalid method: %s. Valid methods are: op, m2", method)
+	}
+
+	return nil
+}
+
+func ValidateLibraryCategory(category string) error {
+	if category == "" {
+		return fmt.Errorf("category cannot be empty")
+	}
+
+	if _, err := models.FindLibraryCategory(category); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func ValidateLibraryCase(id string) error {
+	if id == "" {
+		return fmt.Errorf("case cannot be empty")
+	}
+
+	if _, err := models.FindLibraryCase(id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func ValidateAlgorithmIndex(index, count int) error {
+	if index < 0 || index >= count {
+		return fmt.Errorf("algorithm index must be between 0 and %d", count-1)
+	}
+
+	return nil
+}
+
+// ValidateStoredAlgorithm checks an algorithm before it is saved by applying
+// it to a fresh cube; algorithms that leave the cube unchanged are rejected.
+func ValidateStoredAlgorithm(algorithm string) error {
+	if err := ValidateAlgorithm(algorithm); err != nil {
+		return err
+	}
+
+	alg, _ := models.ParseAlgorithm(algorithm)
+	cube := models.New()
+	cube.Apply(alg)
+	if reflect.DeepEqual(cube, models.New()) {
+		return fmt.Errorf("algorithm does not change the cube")
+	}
+
+	return nil
+}
+
+func ValidateAlgorithmName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+
+	return nil
+}
+
+func ValidateTags(tags []string) error {
+	for _, tag := range tags {
+		if strings.TrimSpace(tag) == "" {
+			return fmt.Errorf("tags cannot be empty")
+		}
+	}
+
+	return nil
+}
+
+// ValidateMoveSet checks an optional move-set restriction such as "<R,U>".
+func ValidateMoveSet(moves string) error {
+	if moves == "" {
+		return nil
+	}
+
+	faces, err := models.ParseMoveSet(moves)
+	if err != nil {
+		return err
+	}
+	if !models.IsSolveMoveSet(faces) {
+		return fmt.Errorf("moves must be one of %s", strings.Join(models.SolveMoveSets(), ", "))
+	}
+
+	return nil
+}
+
+func ValidateMaxLength(maxLength int) error {
+	if maxLength < 0 || maxLength > 30 {
+		return fmt.Errorf("maxLength must be between 0 and 30, where 0 uses the default")
+	}
+
+	return nil
+}
+
+func ValidateStep(step string) error {
+	if step == "" {
+		return fmt.Errorf("step cannot be empty")
+	}
+
+	validSteps := map[string]bool{
+		"cross":   true,
+		"xcross":  true,
+		"eoline":  true,
+		"eocross": true,
+		"fb":      true,
+	}
+
+	if !validSteps[step] {
+		return fmt.Errorf("invalid step: %s. Valid steps are: cross, xcross, eoline, eocross, fb", step)
+	}
+
+	return nil
+}
+
+func ValidateAxis(axis string) error {
+	validAxes := map[string]bool{
+		"fb": true,
+		"rl": true,
+		"ud": true,
+	}
+
+	if !validAxes[axis] {
+		return fmt.Errorf("invalid axis: %s. Valid axes are: fb, rl, ud", axis)
+	}
+
+	return nil
+}
+
+func ValidateLesson(id string) error {
+	if id == "" {
+		return fmt.Errorf("lesson cannot be empty")
+	}
+
+	if _, err := models.FindLesson(id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func ValidateSession(session string) error {
+	if strings.TrimSpace(session) == "" {
+		return fmt.Errorf("session cannot be empty")
+	}
+
+	return nil
+}
+
+func ValidateScrambleSubset(subset string) error {
+	validSubsets := map[string]bool{
+		"full":  true,
+		"ll":    true,
+		"zbll":  true,
+		"pll":   true,
+		"lse":   true,
+		"edges": true,
+	}
+
+	if !validSubsets[subset] {
+		return fmt.Errorf("invalid subset: %s. Valid subsets are: full, ll, zbll, pll, lse, edges", subset)
+	}
+
+	return nil
+}
+
+func ValidateMinCrossLength(length int) error {
+	if length < 0 || length > 8 {
+		return fmt.Errorf("minCrossLength must be between 0 and 8")
+	}
+
+	return nil
+}
+
+func ValidatePlayerName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+	if len(name) > 40 {
+		return fmt.Errorf("name must be at most 40 characters")
+	}
+
+	return nil
+}
+
+func ValidateTimeLimit(seconds int) error {
+	if seconds < 1 || seconds > 86400 {
+		return fmt.Errorf("timeLimit must be between 1 and 86400 seconds")
+	}
+
+	return nil
+}
+
+func ValidateAttempt(attempt string) error {
+	if strings.TrimSpace(attempt) == "" {
+		return fmt.Errorf("attempt cannot be empty")
+	}
+
+	return nil
+}
+
+func ValidateNISS(solution string) error {
+	if strings.TrimSpace(solution) == "" {
+		return fmt.Errorf("algorithm cannot be empty")
+	}
+	if len(solution) > 1000 {
+		return fmt.Errorf("algorithm must be at most 1000 characters")
+	}
+
+	if _, err := models.ParseNISS(solution); err != nil {
+		return fmt.Errorf("invalid algorithm: %v", err)
+	}
+
+	return nil
+}